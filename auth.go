@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TokenSource returns a bearer token to use for a request.
+type TokenSource func(ctx context.Context) (string, error)
+
+// WithBearerToken sets a default Authorization header with the bearer token on every request.
+func (c *RestClient) WithBearerToken(token string) *RestClient {
+	c.setDefaultHeader("Authorization", "Bearer "+token)
+	return c
+}
+
+// BearerAuthModifier returns a request modifier that sets the Authorization header with the bearer token.
+// Example:
+//
+//	response, err := client.GET(client.ResolveURL("/api/v1/users/%s", userID), BearerAuthModifier(token))
+func BearerAuthModifier(token string) func(req *http.Request) {
+	return func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// WithBasicAuth sets a default Authorization header with the basic auth credentials on every request.
+func (c *RestClient) WithBasicAuth(username string, password string) *RestClient {
+	c.setDefaultHeader("Authorization", "Basic "+basicAuth(username, password))
+	return c
+}
+
+// BasicAuthModifier returns a request modifier that sets the Authorization header with the basic auth credentials.
+// Example:
+//
+//	response, err := client.GET(client.ResolveURL("/api/v1/users/%s", userID), BasicAuthModifier(username, password))
+func BasicAuthModifier(username string, password string) func(req *http.Request) {
+	return func(req *http.Request) {
+		req.SetBasicAuth(username, password)
+	}
+}
+
+// basicAuth encodes the credentials the same way as http.Request.SetBasicAuth.
+func basicAuth(username string, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
+
+// WithTokenSource sets a token source that is called before every request to get the bearer token for the
+// Authorization header. Requests that already have an Authorization header, e.g. set by a request modifier, don't
+// call the token source. Errors from the token source are returned as request errors.
+// Use CachedTokenSource to avoid fetching a new token for every request.
+func (c *RestClient) WithTokenSource(source TokenSource) *RestClient {
+	c.tokenSource = source
+	return c
+}
+
+// CachedTokenSource returns a TokenSource that caches the token returned by fetch until it expires.
+// A zero expiry means the token never expires.
+// Example using golang.org/x/oauth2:
+//
+//	client.WithTokenSource(CachedTokenSource(func(ctx context.Context) (string, time.Time, error) {
+//		token, err := oauth2TokenSource.Token()
+//		if err != nil {
+//			return "", time.Time{}, err
+//		}
+//		return token.AccessToken, token.Expiry, nil
+//	}))
+func CachedTokenSource(fetch func(ctx context.Context) (string, time.Time, error)) TokenSource {
+	var mu sync.Mutex
+	var token string
+	var expiry time.Time
+	return func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if token != "" && (expiry.IsZero() || time.Now().Before(expiry)) {
+			return token, nil
+		}
+		newToken, newExpiry, err := fetch(ctx)
+		if err != nil {
+			return "", err
+		}
+		token, expiry = newToken, newExpiry
+		return token, nil
+	}
+}
+
+// authorize sets the Authorization header from the token source, if any.
+func (c *RestClient) authorize(req *http.Request) error {
+	if c.tokenSource == nil || req.Header.Get("Authorization") != "" {
+		return nil
+	}
+	token, err := c.tokenSource(req.Context())
+	if err != nil {
+		return fmt.Errorf("error getting token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// setDefaultHeader replaces any existing default values of the header.
+func (c *RestClient) setDefaultHeader(key string, value string) {
+	if c.headers == nil {
+		c.headers = make(http.Header)
+	}
+	c.headers.Set(key, value)
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBearerAuth(t *testing.T) {
+	var authorization string
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should set the bearer token on every request", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithBearerToken("old").WithBearerToken("token")
+		_, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, "Bearer token", authorization)
+	})
+	t.Run("should set the bearer token with a modifier", func(t *testing.T) {
+		client := NewRestClient("resource", false)
+		_, err := client.GET(srv.URL, BearerAuthModifier("token"))
+		assert.Nil(t, err)
+		assert.Equal(t, "Bearer token", authorization)
+	})
+}
+
+func TestBasicAuth(t *testing.T) {
+	var username, password string
+	var ok bool
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok = r.BasicAuth()
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should set basic auth on every request", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithBasicAuth("john", "secret")
+		_, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "john", username)
+		assert.Equal(t, "secret", password)
+	})
+	t.Run("should set basic auth with a modifier", func(t *testing.T) {
+		client := NewRestClient("resource", false)
+		_, err := client.GET(srv.URL, BasicAuthModifier("jane", "pass"))
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "jane", username)
+		assert.Equal(t, "pass", password)
+	})
+}
+
+func TestWithTokenSource(t *testing.T) {
+	var authorization string
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should set the token from the token source", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithTokenSource(func(ctx context.Context) (string, error) {
+			return "token", nil
+		})
+		_, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, "Bearer token", authorization)
+	})
+	t.Run("should return token source errors", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithTokenSource(func(ctx context.Context) (string, error) {
+			return "", errors.New("token expired")
+		})
+		_, err := client.GET(srv.URL)
+		assert.ErrorContains(t, err, "token expired")
+	})
+	t.Run("should cache tokens until they expire", func(t *testing.T) {
+		calls := 0
+		expiry := time.Now().Add(time.Hour)
+		source := CachedTokenSource(func(ctx context.Context) (string, time.Time, error) {
+			calls++
+			return "token", expiry, nil
+		})
+		client := NewRestClient("resource", false).WithTokenSource(source)
+		_, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		_, err = client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, calls)
+	})
+	t.Run("should fetch a new token when the cached token has expired", func(t *testing.T) {
+		calls := 0
+		source := CachedTokenSource(func(ctx context.Context) (string, time.Time, error) {
+			calls++
+			return "token", time.Now().Add(-time.Second), nil
+		})
+		_, _ = source(context.Background())
+		_, _ = source(context.Background())
+		assert.Equal(t, 2, calls)
+	})
+}
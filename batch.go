@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// BatchResult is the result of a single request of BatchGet, the response body must be closed by the caller.
+type BatchResult struct {
+	URL      string
+	Response *http.Response
+	Err      error
+}
+
+// BatchGet performs GET requests to the urls using at most concurrency requests at a time and returns the results in
+// the order of the urls. Each request uses ctx, once it is done the remaining requests are not sent and fail with
+// the context error. A concurrency <= 0 sends one request at a time.
+// Example:
+//
+//	results := client.BatchGet(ctx, urls, 8)
+//	for _, result := range results {
+//		if result.Err != nil {
+//			continue
+//		}
+//		user, err := Decode[User](result.Response)
+//	}
+func (c *RestClient) BatchGet(ctx context.Context, urls []string, concurrency int, requestModifier ...func(req *http.Request)) []BatchResult {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	modifiers := append([]func(req *http.Request){func(req *http.Request) {
+		*req = *req.WithContext(ctx)
+	}}, requestModifier...)
+
+	results := make([]BatchResult, len(urls))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < len(urls); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				result := BatchResult{URL: urls[index]}
+				if err := ctx.Err(); err != nil {
+					result.Err = err
+				} else {
+					result.Response, result.Err = c.GET(urls[index], modifiers...)
+				}
+				results[index] = result
+			}
+		}()
+	}
+	for i := range urls {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchGet(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				maxSeen := maxInFlight.Load()
+				if current <= maxSeen || maxInFlight.CompareAndSwap(maxSeen, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			if r.URL.Path == "/missing" {
+				w.WriteHeader(http.StatusNotFound)
+			}
+			_, _ = w.Write([]byte(r.URL.Path))
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should return results in order with bounded concurrency", func(t *testing.T) {
+		maxInFlight.Store(0)
+		client := NewRestClient("resource", false).WithErrorOnStatus()
+		urls := []string{srv.URL + "/a", srv.URL + "/b", srv.URL + "/missing", srv.URL + "/c", srv.URL + "/d"}
+
+		results := client.BatchGet(context.Background(), urls, 2)
+		assert.Len(t, results, len(urls))
+		for i, result := range results {
+			assert.Equal(t, urls[i], result.URL)
+			if i == 2 {
+				var httpErr *HTTPError
+				assert.ErrorAs(t, result.Err, &httpErr)
+				continue
+			}
+			assert.Nil(t, result.Err)
+			body, _ := io.ReadAll(result.Response.Body)
+			_ = result.Response.Body.Close()
+			assert.Equal(t, urls[i][len(srv.URL):], string(body))
+		}
+		assert.LessOrEqual(t, maxInFlight.Load(), int32(2))
+	})
+	t.Run("should fail the remaining requests when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results := NewRestClient("resource", false).BatchGet(ctx, []string{srv.URL, srv.URL}, 1)
+		for _, result := range results {
+			assert.ErrorIs(t, result.Err, context.Canceled)
+			assert.Nil(t, result.Response)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"container/list"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseCache is an in-memory LRU cache of GET responses, it is safe for concurrent use.
+type responseCache struct {
+	ttl        time.Duration
+	maxEntries int
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List
+	now        func() time.Time
+}
+
+// cacheEntry is a cached response.
+type cacheEntry struct {
+	key        string
+	status     string
+	statusCode int
+	proto      string
+	header     http.Header
+	body       []byte
+	storedAt   time.Time
+}
+
+// WithResponseCache caches successful GET responses in memory for ttl, keyed by URL and request headers, keeping at
+// most maxEntries responses and evicting the least recently used ones, a maxEntries <= 0 means no limit.
+// Requests with different headers, e.g. the Authorization header of different users, never share a response.
+// Responses with Cache-Control: no-store are not cached. When a cached response with an ETag expires it is
+// revalidated with If-None-Match, and reused if the server responds with 304 Not Modified.
+// Requests that set If-None-Match themselves bypass the cache. Cache hits return a copy of the response.
+// Example:
+//
+//	client := NewRestClient("dashboard", true).WithResponseCache(30*time.Second, 100)
+func (c *RestClient) WithResponseCache(ttl time.Duration, maxEntries int) *RestClient {
+	c.cache = &responseCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+	return c
+}
+
+// doCached sends a GET request using the response cache.
+func (c *RestClient) doCached(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("If-None-Match") != "" || hasCacheDirective(req.Header, "no-store") {
+		return c.doUncached(req)
+	}
+
+	key := requestKey(req)
+	entry, fresh := c.cache.get(key)
+	if fresh {
+		return entry.response(req), nil
+	}
+	etag := ""
+	if entry != nil {
+		etag = entry.header.Get("ETag")
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.doUncached(req)
+	if err != nil {
+		return nil, err
+	}
+	if etag != "" && resp.StatusCode == http.StatusNotModified {
+		_ = resp.Body.Close()
+		c.cache.refresh(key)
+		return entry.response(req), nil
+	}
+	if resp.StatusCode != http.StatusOK || hasCacheDirective(resp.Header, "no-store") {
+		return resp, nil
+	}
+
+	body, err := BufferResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.put(&cacheEntry{
+		key:        key,
+		status:     resp.Status,
+		statusCode: resp.StatusCode,
+		proto:      resp.Proto,
+		header:     resp.Header.Clone(),
+		body:       body,
+	})
+	return resp, nil
+}
+
+// requestKey returns a key identifying requests with the same URL and headers, which can share a response.
+func requestKey(req *http.Request) string {
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var key strings.Builder
+	key.WriteString(req.URL.String())
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			key.WriteString("\n" + name + ": " + value)
+		}
+	}
+	return key.String()
+}
+
+// get returns the entry for the key, if any, and whether it is still fresh.
+func (rc *responseCache) get(key string) (*cacheEntry, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	element, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	rc.order.MoveToFront(element)
+	entry := element.Value.(*cacheEntry)
+	return entry, rc.now().Sub(entry.storedAt) < rc.ttl
+}
+
+// put adds the entry, evicting the least recently used entry when the cache is full.
+func (rc *responseCache) put(entry *cacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry.storedAt = rc.now()
+	if element, ok := rc.entries[entry.key]; ok {
+		element.Value = entry
+		rc.order.MoveToFront(element)
+		return
+	}
+	rc.entries[entry.key] = rc.order.PushFront(entry)
+	if rc.maxEntries > 0 && rc.order.Len() > rc.maxEntries {
+		oldest := rc.order.Back()
+		rc.order.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// refresh marks the entry for the key as fresh again after it was revalidated.
+func (rc *responseCache) refresh(key string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if element, ok := rc.entries[key]; ok {
+		entry := *element.Value.(*cacheEntry)
+		entry.storedAt = rc.now()
+		element.Value = &entry
+	}
+}
+
+// response returns a new response with a copy of the cached response.
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        e.status,
+		StatusCode:    e.statusCode,
+		Proto:         e.proto,
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          newBufferedBody(e.body),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// hasCacheDirective returns true if the Cache-Control header contains the directive.
+func hasCacheDirective(header http.Header, directive string) bool {
+	for _, value := range header.Values("Cache-Control") {
+		for _, d := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(d), directive) {
+				return true
+			}
+		}
+	}
+	return false
+}
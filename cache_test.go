@@ -0,0 +1,159 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithResponseCache(t *testing.T) {
+	newServer := func(handler func(w http.ResponseWriter, r *http.Request)) (*httptest.Server, *atomic.Int32) {
+		var hits atomic.Int32
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				handler(w, r)
+			}),
+		)
+		return srv, &hits
+	}
+	readBody := func(t *testing.T, resp *http.Response) string {
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		assert.Nil(t, err)
+		return string(body)
+	}
+
+	t.Run("should return cached responses until the ttl expires", func(t *testing.T) {
+		srv, hits := newServer(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		})
+		defer srv.Close()
+
+		now := time.Now()
+		client := NewRestClient("resource", false).WithResponseCache(time.Minute, 10)
+		client.cache.now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			resp, err := client.GET(srv.URL)
+			assert.Nil(t, err)
+			assert.Equal(t, "ok", readBody(t, resp))
+		}
+		assert.Equal(t, int32(1), hits.Load())
+
+		now = now.Add(time.Minute)
+		resp, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, "ok", readBody(t, resp))
+		assert.Equal(t, int32(2), hits.Load())
+	})
+	t.Run("should key the cache by URL and Accept header", func(t *testing.T) {
+		srv, hits := newServer(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.URL.Path + " " + r.Header.Get("Accept")))
+		})
+		defer srv.Close()
+
+		client := NewRestClient("resource", false).WithResponseCache(time.Minute, 10)
+		resp, _ := client.GET(srv.URL + "/a")
+		assert.Equal(t, "/a application/json", readBody(t, resp))
+		resp, _ = client.GET(srv.URL + "/b")
+		assert.Equal(t, "/b application/json", readBody(t, resp))
+		resp, _ = client.GET(srv.URL+"/a", func(req *http.Request) {
+			req.Header.Set("Accept", "text/plain")
+		})
+		assert.Equal(t, "/a text/plain", readBody(t, resp))
+		assert.Equal(t, int32(3), hits.Load())
+	})
+	t.Run("should not share responses between requests with different headers", func(t *testing.T) {
+		srv, hits := newServer(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.Header.Get("Authorization") + " " + r.Header.Get("X-Tenant")))
+		})
+		defer srv.Close()
+
+		client := NewRestClient("resource", false).WithResponseCache(time.Minute, 10)
+		resp, _ := client.GET(srv.URL, BearerAuthModifier("alice"))
+		assert.Equal(t, "Bearer alice ", readBody(t, resp))
+		resp, _ = client.GET(srv.URL, BearerAuthModifier("bob"))
+		assert.Equal(t, "Bearer bob ", readBody(t, resp))
+		resp, _ = client.GET(srv.URL, BearerAuthModifier("bob"), func(req *http.Request) {
+			req.Header.Set("X-Tenant", "acme")
+		})
+		assert.Equal(t, "Bearer bob acme", readBody(t, resp))
+		assert.Equal(t, int32(3), hits.Load())
+
+		resp, _ = client.GET(srv.URL, BearerAuthModifier("alice"))
+		assert.Equal(t, "Bearer alice ", readBody(t, resp))
+		assert.Equal(t, int32(3), hits.Load())
+	})
+	t.Run("should not cache responses with no-store", func(t *testing.T) {
+		srv, hits := newServer(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", "private, no-store")
+		})
+		defer srv.Close()
+
+		client := NewRestClient("resource", false).WithResponseCache(time.Minute, 10)
+		_, _ = client.GET(srv.URL)
+		_, _ = client.GET(srv.URL)
+		assert.Equal(t, int32(2), hits.Load())
+	})
+	t.Run("should not cache other methods or unsuccessful responses", func(t *testing.T) {
+		srv, hits := newServer(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/missing" {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+		defer srv.Close()
+
+		client := NewRestClient("resource", false).WithResponseCache(time.Minute, 10)
+		_, _ = client.POST(srv.URL, nil)
+		_, _ = client.POST(srv.URL, nil)
+		_, _ = client.GET(srv.URL + "/missing")
+		_, _ = client.GET(srv.URL + "/missing")
+		assert.Equal(t, int32(4), hits.Load())
+	})
+	t.Run("should revalidate expired responses with the ETag", func(t *testing.T) {
+		var ifNoneMatch string
+		srv, hits := newServer(func(w http.ResponseWriter, r *http.Request) {
+			ifNoneMatch = r.Header.Get("If-None-Match")
+			if ifNoneMatch == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = w.Write([]byte("v1"))
+		})
+		defer srv.Close()
+
+		client := NewRestClient("resource", false).WithResponseCache(0, 10)
+		resp, _ := client.GET(srv.URL)
+		assert.Equal(t, "v1", readBody(t, resp))
+
+		resp, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "v1", readBody(t, resp))
+		assert.Equal(t, `"v1"`, ifNoneMatch)
+		assert.Equal(t, int32(2), hits.Load())
+	})
+	t.Run("should evict the least recently used entry", func(t *testing.T) {
+		srv, hits := newServer(func(w http.ResponseWriter, r *http.Request) {})
+		defer srv.Close()
+
+		client := NewRestClient("resource", false).WithResponseCache(time.Minute, 2)
+		_, _ = client.GET(srv.URL + "/a")
+		_, _ = client.GET(srv.URL + "/b")
+		_, _ = client.GET(srv.URL + "/a")
+		_, _ = client.GET(srv.URL + "/c")
+		assert.Equal(t, int32(3), hits.Load())
+
+		_, _ = client.GET(srv.URL + "/a")
+		assert.Equal(t, int32(3), hits.Load())
+		_, _ = client.GET(srv.URL + "/b")
+		assert.Equal(t, int32(4), hits.Load())
+	})
+}
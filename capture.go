@@ -0,0 +1,111 @@
+package client
+
+import (
+	"mime"
+	"net/http"
+)
+
+// capture holds the last request and response of a client in capture mode.
+type capture struct {
+	request      *http.Request
+	requestBody  []byte
+	response     *http.Response
+	responseBody []byte
+}
+
+// WithCapture keeps a copy of the last request and response, including their bodies, so tests can assert on what
+// was sent and received, see LastRequest and LastResponse. Bodies are read into memory, so only use it for debugging
+// and tests.
+// Example:
+//
+//	client := NewRestClientWithBaseURL(srv.URL).WithCapture()
+//	_, err := client.POST(client.ResolveURL("/users"), user)
+//	assert.Equal(t, "application/json", client.LastRequest().Header.Get("Content-Type"))
+func (c *RestClient) WithCapture() *RestClient {
+	c.capture = &capture{}
+	return c
+}
+
+// LastRequest returns a copy of the last request sent with its body, or nil if there is none or capture mode is off.
+// It is captured as sent, including headers added when sending like the signature, and is the last attempt when
+// the request was retried.
+func (c *RestClient) LastRequest() *http.Request {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.capture == nil || c.capture.request == nil {
+		return nil
+	}
+	req := c.capture.request.Clone(c.capture.request.Context())
+	if c.capture.requestBody != nil {
+		req.Body = newBufferedBody(c.capture.requestBody)
+	}
+	return req
+}
+
+// LastResponse returns a copy of the last response received with its body, or nil if there is none or capture mode
+// is off. The body of an event stream, see SubscribeEvents, is not captured and empty.
+func (c *RestClient) LastResponse() *http.Response {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.capture == nil || c.capture.response == nil {
+		return nil
+	}
+	resp := *c.capture.response
+	resp.Header = resp.Header.Clone()
+	resp.Body = newBufferedBody(c.capture.responseBody)
+	return &resp
+}
+
+// captureRequest stores a copy of the request, if capture mode is on.
+func (c *RestClient) captureRequest(req *http.Request) error {
+	if c.capture == nil {
+		return nil
+	}
+	body, err := peekBody(req)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capture.request = req.Clone(req.Context())
+	c.capture.request.Body = nil
+	c.capture.request.GetBody = nil
+	c.capture.requestBody = body
+	c.capture.response = nil
+	c.capture.responseBody = nil
+	return nil
+}
+
+// captureResponse buffers the response body and stores a copy of the response, if capture mode is on. The body of
+// an event stream never ends, so it is not captured.
+func (c *RestClient) captureResponse(resp *http.Response) error {
+	if c.capture == nil {
+		return nil
+	}
+	var body []byte
+	if !isEventStream(resp) {
+		var err error
+		if body, err = BufferResponse(resp); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	captured := *resp
+	captured.Header = resp.Header.Clone()
+	captured.Body = nil
+	c.capture.response = &captured
+	c.capture.responseBody = body
+	return nil
+}
+
+// isEventStream returns true if the response is a Server-Sent Events stream, or was requested as one.
+func isEventStream(resp *http.Response) bool {
+	if resp.Request != nil && resp.Request.Header.Get("Accept") == eventStreamContentType {
+		return true
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return mediaType == eventStreamContentType
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCapture(t *testing.T) {
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"1"}`))
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should capture the last request and response", func(t *testing.T) {
+		client := NewRestClientWithBaseURL(srv.URL).WithCapture().WithBearerToken("token")
+		created, err := Post[map[string]string](client, client.ResolveURL("/users"), map[string]string{"name": "john"})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"id": "1"}, created)
+
+		req := client.LastRequest()
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, srv.URL+"/users", req.URL.String())
+		assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, `{"name":"john"}`, string(body))
+
+		resp := client.LastResponse()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		body, _ = io.ReadAll(resp.Body)
+		assert.Equal(t, `{"id":"1"}`, string(body))
+	})
+	t.Run("should return new copies on every call", func(t *testing.T) {
+		client := NewRestClientWithBaseURL(srv.URL).WithCapture()
+		_, err := client.GET(client.ResolveURL("/users"))
+		assert.Nil(t, err)
+
+		first := client.LastResponse()
+		_, _ = io.ReadAll(first.Body)
+		first.Header.Set("Content-Type", "changed")
+
+		second := client.LastResponse()
+		body, _ := io.ReadAll(second.Body)
+		assert.Equal(t, `{"id":"1"}`, string(body))
+		assert.Equal(t, "application/json", second.Header.Get("Content-Type"))
+	})
+	t.Run("should capture the last attempt as sent", func(t *testing.T) {
+		var attempts atomic.Int32
+		retrySrv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}),
+		)
+		defer retrySrv.Close()
+
+		signatures := 0
+		client := NewRestClientWithBaseURL(retrySrv.URL).
+			WithCapture().
+			WithRetry(2, time.Millisecond).
+			WithRequestSigner(RequestSignerFunc(func(req *http.Request) error {
+				signatures++
+				req.Header.Set("Signature", fmt.Sprintf("sig-%d", signatures))
+				return nil
+			}))
+		_, err := client.PUT(client.ResolveURL("/users/1"), map[string]string{"name": "john"})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), attempts.Load())
+
+		req := client.LastRequest()
+		assert.Equal(t, "sig-2", req.Header.Get("Signature"))
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, `{"name":"john"}`, string(body))
+		assert.Equal(t, http.StatusOK, client.LastResponse().StatusCode)
+	})
+	t.Run("should return nil when capture mode is off", func(t *testing.T) {
+		client := NewRestClientWithBaseURL(srv.URL)
+		_, err := client.GET(client.ResolveURL("/users"))
+		assert.Nil(t, err)
+		assert.Nil(t, client.LastRequest())
+		assert.Nil(t, client.LastResponse())
+	})
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/transform"
+)
+
+// ErrUnsupportedCharset is returned for responses with a charset that can't be decoded. Decode returns it for JSON
+// responses with a charset other than UTF-8, unless they are converted with WithCharsetDecoding.
+var ErrUnsupportedCharset = errors.New("unsupported charset")
+
+// WithCharsetDecoding converts the body of text responses, i.e. text/*, JSON and XML, declared with a charset other
+// than UTF-8 in the Content-Type header to UTF-8, and sets the charset of the header to utf-8. Charsets are looked up
+// by their WHATWG encoding labels, e.g. "iso-8859-1", "windows-1252" or "shift_jis". Requests fail with
+// ErrUnsupportedCharset if the charset is unknown.
+// Example:
+//
+//	client := NewRestClient("legacy", true).WithCharsetDecoding()
+func (c *RestClient) WithCharsetDecoding() *RestClient {
+	c.charsetDecoding = true
+	return c
+}
+
+// decodeCharset wraps the body of a text response in a reader converting it to UTF-8 if charset decoding is enabled.
+func (c *RestClient) decodeCharset(resp *http.Response) error {
+	if !c.charsetDecoding || resp.Body == nil || resp.Body == http.NoBody {
+		return nil
+	}
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || isUTF8Charset(params["charset"]) || !isTextMediaType(mediaType) {
+		return nil
+	}
+
+	encoding, err := htmlindex.Get(params["charset"])
+	if err != nil {
+		_ = resp.Body.Close()
+		return fmt.Errorf("%w %q in response content type", ErrUnsupportedCharset, params["charset"])
+	}
+	resp.Body = &charsetBody{
+		Reader: transform.NewReader(resp.Body, encoding.NewDecoder()),
+		body:   resp.Body,
+	}
+	params["charset"] = "utf-8"
+	resp.Header.Set("Content-Type", mime.FormatMediaType(mediaType, params))
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	return nil
+}
+
+// charsetBody reads the converted body and closes the original one.
+type charsetBody struct {
+	io.Reader
+	body io.ReadCloser
+}
+
+func (b *charsetBody) Close() error {
+	return b.body.Close()
+}
+
+// checkUTF8Charset returns a DecodeError if the content type declares a charset other than UTF-8.
+func checkUTF8Charset(contentType string) error {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil || isUTF8Charset(params["charset"]) {
+		return nil
+	}
+	return &DecodeError{
+		ContentType: contentType,
+		Err:         fmt.Errorf("%w %q, the body must be UTF-8, see WithCharsetDecoding", ErrUnsupportedCharset, params["charset"]),
+	}
+}
+
+// isUTF8Charset returns true if the charset is empty, UTF-8 or its ASCII subset.
+func isUTF8Charset(charset string) bool {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "", "utf-8", "utf8", "us-ascii", "ascii":
+		return true
+	}
+	return false
+}
+
+// isTextMediaType returns true for media types with a body in a charset.
+func isTextMediaType(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") || mediaType == "application/xml" || strings.HasSuffix(mediaType, "+xml") ||
+		isNDJSONContentType(mediaType)
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharsetDecoding(t *testing.T) {
+	latin1 := func(contentType string, body string) *MockTransport {
+		return NewMockTransport().Respond(http.MethodGet, "/users/1", MockResponse{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{contentType}},
+			Body:       []byte(body),
+		})
+	}
+
+	t.Run("should convert text responses to UTF-8", func(t *testing.T) {
+		client := NewRestClientWithBaseURL("http://users").
+			WithTransport(latin1("text/plain; charset=ISO-8859-1", "J\xf8rgen")).
+			WithCharsetDecoding()
+
+		resp, err := client.GET(client.ResolveURL("/users/1"))
+		assert.Nil(t, err)
+		body, err := io.ReadAll(resp.Body)
+		assert.Nil(t, err)
+		assert.Nil(t, resp.Body.Close())
+		assert.Equal(t, "Jørgen", string(body))
+		assert.Equal(t, "text/plain; charset=utf-8", resp.Header.Get("Content-Type"))
+		assert.Equal(t, int64(-1), resp.ContentLength)
+	})
+	t.Run("should decode JSON responses converted to UTF-8", func(t *testing.T) {
+		client := NewRestClientWithBaseURL("http://users").
+			WithTransport(latin1("application/json; charset=windows-1252", "{\"name\":\"J\xf8rgen\"}")).
+			WithCharsetDecoding()
+
+		user, err := Get[map[string]string](client, client.ResolveURL("/users/1"))
+		assert.Nil(t, err)
+		assert.Equal(t, "Jørgen", user["name"])
+	})
+	t.Run("should leave UTF-8 and binary responses unchanged", func(t *testing.T) {
+		for _, contentType := range []string{"text/plain; charset=utf-8", "text/plain", "application/octet-stream; charset=latin1"} {
+			client := NewRestClientWithBaseURL("http://users").
+				WithTransport(latin1(contentType, "J\xf8rgen")).
+				WithCharsetDecoding()
+
+			resp, err := client.GET(client.ResolveURL("/users/1"))
+			assert.Nil(t, err)
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, "J\xf8rgen", string(body), contentType)
+			assert.Equal(t, contentType, resp.Header.Get("Content-Type"))
+		}
+	})
+	t.Run("should fail for unknown charsets", func(t *testing.T) {
+		client := NewRestClientWithBaseURL("http://users").
+			WithTransport(latin1("text/plain; charset=unknown", "text")).
+			WithCharsetDecoding()
+
+		_, err := client.GET(client.ResolveURL("/users/1"))
+		assert.ErrorIs(t, err, ErrUnsupportedCharset)
+	})
+	t.Run("should fail decoding JSON with a non UTF-8 charset without charset decoding", func(t *testing.T) {
+		client := NewRestClientWithBaseURL("http://users").
+			WithTransport(latin1("application/json; charset=ISO-8859-1", "{\"name\":\"J\xf8rgen\"}"))
+
+		_, err := Get[map[string]string](client, client.ResolveURL("/users/1"))
+		assert.ErrorIs(t, err, ErrUnsupportedCharset)
+		var decodeErr *DecodeError
+		assert.True(t, errors.As(err, &decodeErr))
+		assert.Equal(t, "application/json; charset=ISO-8859-1", decodeErr.ContentType)
+	})
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreakerSettings configures the circuit breaker of a RestClient.
+type CircuitBreakerSettings struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit.
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before a single probe request is let through.
+	Cooldown time.Duration
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker tracks consecutive failures of a client, it is safe for concurrent use.
+type circuitBreaker struct {
+	settings CircuitBreakerSettings
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+	now      func() time.Time
+}
+
+// WithCircuitBreaker enables a circuit breaker that opens after settings.FailureThreshold consecutive failed
+// requests, a failure being a request error or a 5xx response. Requests cancelled by their context are not counted. While open, requests fail fast with ErrCircuitOpen.
+// After settings.Cooldown a single probe request is let through, closing the circuit if it succeeds and opening it
+// again if it fails.
+func (c *RestClient) WithCircuitBreaker(settings CircuitBreakerSettings) *RestClient {
+	c.breaker = &circuitBreaker{settings: settings, now: time.Now}
+	return c
+}
+
+// allow returns ErrCircuitOpen if the request must not be sent.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if b.now().Sub(b.openedAt) < b.settings.Cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = circuitHalfOpen
+		return nil
+	case circuitHalfOpen:
+		// a probe is already in flight
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+// run sends the request that was allowed and records its result. If sending panics, e.g. in a middleware, the probe
+// of a half-open circuit is released so the circuit doesn't stay half-open forever.
+func (b *circuitBreaker) run(req *http.Request, send func(req *http.Request) (*http.Response, error)) (*http.Response, error) {
+	recorded := false
+	defer func() {
+		if !recorded {
+			b.release()
+		}
+	}()
+	resp, err := send(req)
+	b.record(resp, err)
+	recorded = true
+	return resp, err
+}
+
+// record updates the breaker state with the result of a request that was allowed. Requests cancelled by the caller
+// say nothing about the health of the backend and are not counted.
+func (b *circuitBreaker) record(resp *http.Response, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if errors.Is(err, context.Canceled) {
+		b.releaseProbe()
+		return
+	}
+	if err == nil && resp.StatusCode < 500 {
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.settings.FailureThreshold {
+		b.state = circuitOpen
+		b.openedAt = b.now()
+	}
+}
+
+// release lets another request probe the backend if the request that was allowed as probe never completed.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.releaseProbe()
+}
+
+// releaseProbe reopens a half-open circuit with the cooldown already elapsed, so the next request is the probe.
+// The lock must be held.
+func (b *circuitBreaker) releaseProbe() {
+	if b.state == circuitHalfOpen {
+		b.state = circuitOpen
+	}
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	var failing atomic.Bool
+	var calls int32
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			if failing.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should open after consecutive failures and recover after the cooldown", func(t *testing.T) {
+		now := time.Now()
+		client := NewRestClient("resource", false).WithCircuitBreaker(CircuitBreakerSettings{
+			FailureThreshold: 2,
+			Cooldown:         time.Minute,
+		})
+		client.breaker.now = func() time.Time { return now }
+
+		failing.Store(true)
+		for i := 0; i < 2; i++ {
+			_, err := client.GET(srv.URL)
+			assert.Nil(t, err)
+		}
+
+		atomic.StoreInt32(&calls, 0)
+		_, err := client.GET(srv.URL)
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+
+		// the probe fails and opens the circuit again
+		now = now.Add(time.Minute)
+		_, err = client.GET(srv.URL)
+		assert.Nil(t, err)
+		_, err = client.GET(srv.URL)
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+
+		// the probe succeeds and closes the circuit
+		failing.Store(false)
+		now = now.Add(time.Minute)
+		_, err = client.GET(srv.URL)
+		assert.Nil(t, err)
+		_, err = client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+	t.Run("should reset the failure count on success", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithCircuitBreaker(CircuitBreakerSettings{
+			FailureThreshold: 2,
+			Cooldown:         time.Minute,
+		})
+
+		failing.Store(true)
+		_, _ = client.GET(srv.URL)
+		failing.Store(false)
+		_, _ = client.GET(srv.URL)
+		failing.Store(true)
+		_, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		_, err = client.GET(srv.URL)
+		assert.Nil(t, err)
+	})
+	t.Run("should not count requests cancelled by the caller", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithCircuitBreaker(CircuitBreakerSettings{
+			FailureThreshold: 2,
+			Cooldown:         time.Minute,
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		for i := 0; i < 3; i++ {
+			_, err := client.GET(srv.URL, func(req *http.Request) {
+				*req = *req.WithContext(ctx)
+			})
+			assert.ErrorIs(t, err, context.Canceled)
+		}
+
+		failing.Store(false)
+		_, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+	})
+	t.Run("should release a probe that was cancelled", func(t *testing.T) {
+		now := time.Now()
+		client := NewRestClient("resource", false).WithCircuitBreaker(CircuitBreakerSettings{
+			FailureThreshold: 1,
+			Cooldown:         time.Minute,
+		})
+		client.breaker.now = func() time.Time { return now }
+
+		failing.Store(true)
+		_, _ = client.GET(srv.URL)
+		now = now.Add(time.Minute)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := client.GET(srv.URL, func(req *http.Request) {
+			*req = *req.WithContext(ctx)
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+
+		failing.Store(false)
+		_, err = client.GET(srv.URL)
+		assert.Nil(t, err)
+	})
+	t.Run("should release a probe when sending panics", func(t *testing.T) {
+		now := time.Now()
+		var panicking atomic.Bool
+		client := NewRestClient("resource", false).
+			WithCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1, Cooldown: time.Minute}).
+			WithMiddleware(func(next RoundTripperFunc) RoundTripperFunc {
+				return func(req *http.Request) (*http.Response, error) {
+					if panicking.Load() {
+						panic("middleware failed")
+					}
+					return next(req)
+				}
+			})
+		client.breaker.now = func() time.Time { return now }
+
+		failing.Store(true)
+		_, _ = client.GET(srv.URL)
+		now = now.Add(time.Minute)
+
+		panicking.Store(true)
+		assert.Panics(t, func() {
+			_, _ = client.GET(srv.URL)
+		})
+		panicking.Store(false)
+
+		failing.Store(false)
+		_, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+	})
+}
@@ -2,32 +2,89 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	sdkgoconfig "github.com/kapetacom/sdk-go-config"
 	"github.com/kapetacom/sdk-go-config/providers"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
 )
 
 const (
-	serviceType = "rest"
+	defaultServiceType = "rest"
+	defaultAccept      = "application/json"
 )
 
 type RestClient struct {
-	BaseURL      string
-	resourceName string
-	ready        bool
-	mu           sync.Mutex
+	BaseURL           string
+	HTTPClient        *http.Client
+	resourceName      string
+	name              string
+	serviceType       string
+	timeout           time.Duration
+	retry             retryConfig
+	errOnStatus       bool
+	headers           http.Header
+	tokenSource       TokenSource
+	provider          providers.ConfigProvider
+	initErr           error
+	tracer            trace.Tracer
+	middlewares       []Middleware
+	logger            *slog.Logger
+	redactedHeaders   []string
+	logCurl           bool
+	metrics           MetricsRecorder
+	breaker           *circuitBreaker
+	limiter           *rate.Limiter
+	limitNonBlocking  bool
+	compress          bool
+	compressThreshold int64
+	maxResponseBytes  int64
+	pingPath          string
+	cache             *responseCache
+	flight            *singleflight.Group
+	signer            RequestSigner
+	idempotencyKeys   bool
+	capture           *capture
+	baseCtx           context.Context
+	codec             *jsonCodec
+	strictDecoding    bool
+	charsetDecoding   bool
+	ready             bool
+	closed            bool
+	socketPath        string
+	dialSocketPath    atomic.Value
+	socketDialer      bool
+	readyCh           chan struct{}
+	mu                sync.Mutex
 }
 
 // NewRestClient initializes a new RestClient, use autoInit to automatically initialize the client when the configuration is ready.
 func NewRestClient(resourceName string, autoInit bool) *RestClient {
+	return NewRestClientWithType(resourceName, defaultServiceType, autoInit)
+}
+
+// NewRestClientWithType initializes a new RestClient that resolves the service address using the given port type
+// instead of "rest". Unlike WithServiceType, the type is known before autoInit resolves the address.
+// Example:
+//
+//	client := NewRestClientWithType("users", "http", true)
+func NewRestClientWithType(resourceName, serviceType string, autoInit bool) *RestClient {
 	client := &RestClient{
 		resourceName: resourceName,
+		serviceType:  serviceType,
 	}
 
 	if autoInit {
@@ -39,64 +96,755 @@ func NewRestClient(resourceName string, autoInit bool) *RestClient {
 	return client
 }
 
+// NewRestClientWithBaseURL initializes a new RestClient that sends requests to the given base URL, without
+// resolving a service address from a ConfigProvider. The client is ready immediately.
+// A base URL like unix:///var/run/app.sock connects to a unix domain socket, like a service address can.
+// Example:
+//
+//	client := NewRestClientWithBaseURL("http://localhost:8080")
+func NewRestClientWithBaseURL(baseURL string) *RestClient {
+	client := &RestClient{
+		serviceType: defaultServiceType,
+		ready:       true,
+	}
+	client.setBaseURL(strings.TrimSuffix(baseURL, "/"))
+	close(client.readyChan())
+	return client
+}
+
 // WithConfigProvider initializes the RestClient with a specific ConfigProvider.
 func (c *RestClient) WithConfigProvider(config providers.ConfigProvider) *RestClient {
 	c.init(config)
 	return c
 }
 
+// ResourceName returns the name of the resource the client resolves its service address for.
+func (c *RestClient) ResourceName() string {
+	return c.resourceName
+}
+
+// WithName sets a human-readable name of the client included in logs, span names and metrics, to tell apart clients
+// of different backends. The resource name is used by default.
+func (c *RestClient) WithName(name string) *RestClient {
+	c.name = name
+	return c
+}
+
+// Name returns the name of the client set with WithName, or the resource name if none was set.
+func (c *RestClient) Name() string {
+	if c.name == "" {
+		return c.resourceName
+	}
+	return c.name
+}
+
+// ServiceType returns the port type used to resolve the service address, "rest" by default.
+func (c *RestClient) ServiceType() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.serviceType
+}
+
+// WithServiceType sets the port type used to resolve the service address, for resources that register their
+// port under a different name than "rest". If the client was already initialized the address is resolved again.
+// Example:
+//
+//	client := NewRestClient("users", true).WithServiceType("http")
+func (c *RestClient) WithServiceType(serviceType string) *RestClient {
+	c.mu.Lock()
+	c.serviceType = serviceType
+	provider := c.provider
+	c.mu.Unlock()
+
+	if provider != nil {
+		c.init(provider)
+	}
+	return c
+}
+
+// WithHTTPClient sets the http.Client used to perform requests, http.DefaultClient is used when nil.
+func (c *RestClient) WithHTTPClient(httpClient *http.Client) *RestClient {
+	c.HTTPClient = httpClient
+	return c
+}
+
+// httpClient returns the configured http.Client or http.DefaultClient if none is set.
+func (c *RestClient) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// WithTimeout sets a timeout applied to every request, a zero value means no timeout.
+// The timeout is applied as a deadline on the request context, so if the request context already has an earlier
+// deadline that one takes precedence. The deadline also covers reading the response body.
+// When the timeout is exceeded the returned error wraps context.DeadlineExceeded.
+func (c *RestClient) WithTimeout(timeout time.Duration) *RestClient {
+	c.timeout = timeout
+	return c
+}
+
+// WithBaseContext sets the context used as parent of the context of every request, e.g. one that is cancelled when
+// the service shuts down to abort all requests in flight. A request modifier setting its own context replaces it.
+// Example:
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer stop()
+//	client := NewRestClient("users", true).WithBaseContext(ctx)
+func (c *RestClient) WithBaseContext(ctx context.Context) *RestClient {
+	c.baseCtx = ctx
+	return c
+}
+
+// baseContext returns the context set with WithBaseContext or context.Background.
+func (c *RestClient) baseContext() context.Context {
+	if c.baseCtx == nil {
+		return context.Background()
+	}
+	return c.baseCtx
+}
+
+// requestTimeoutKey is the context key of the timeout set with WithRequestTimeout.
+type requestTimeoutKey struct{}
+
+// WithRequestTimeout returns a request modifier that sets the timeout of a single request, replacing the timeout set
+// with WithTimeout. Like the client timeout it applies to every attempt when retries are enabled.
+// Example:
+//
+//	response, err := client.GET(client.ResolveURL("/api/v1/export"), WithRequestTimeout(5*time.Minute))
+func WithRequestTimeout(timeout time.Duration) func(req *http.Request) {
+	return func(req *http.Request) {
+		*req = *req.WithContext(context.WithValue(req.Context(), requestTimeoutKey{}, timeout))
+	}
+}
+
+// WithErrorOnStatus makes the request methods return an *HTTPError when the response status code is 400 or above.
+// The response body is read into the error and closed.
+func (c *RestClient) WithErrorOnStatus() *RestClient {
+	c.errOnStatus = true
+	return c
+}
+
+// WithDefaultHeader adds a header that is set on every request before the request modifiers run, so a modifier
+// can still override it.
+func (c *RestClient) WithDefaultHeader(key string, value string) *RestClient {
+	if c.headers == nil {
+		c.headers = make(http.Header)
+	}
+	c.headers.Add(key, value)
+	return c
+}
+
+// WithDefaultHeaders adds headers that are set on every request before the request modifiers run, so a modifier
+// can still override them.
+func (c *RestClient) WithDefaultHeaders(headers http.Header) *RestClient {
+	for key, values := range headers {
+		for _, value := range values {
+			c.WithDefaultHeader(key, value)
+		}
+	}
+	return c
+}
+
+// WithBaseHeaders replaces all default headers with a copy of headers. Every request starts from its own copy of
+// the base headers, so a request modifier can change or delete them without affecting other requests.
+// Example:
+//
+//	client := NewRestClient("users", true).WithBaseHeaders(http.Header{"X-Tenant": []string{"acme"}})
+//	resp, err := client.GET(url, func(req *http.Request) {
+//		req.Header.Del("X-Tenant")
+//	})
+func (c *RestClient) WithBaseHeaders(headers http.Header) *RestClient {
+	c.headers = headers.Clone()
+	return c
+}
+
+// WithMaxResponseBytes limits the size of response bodies, reading more than n bytes from a body fails with
+// ErrResponseTooLarge, so a runaway response fails fast instead of exhausting memory when it is decoded.
+// The limit applies to the decompressed body. A value <= 0 means no limit.
+func (c *RestClient) WithMaxResponseBytes(n int64) *RestClient {
+	c.maxResponseBytes = n
+	return c
+}
+
+// limitResponse wraps the response body to enforce the max response size, if any.
+func (c *RestClient) limitResponse(resp *http.Response) {
+	if c.maxResponseBytes <= 0 || resp.Body == nil || resp.Body == http.NoBody {
+		return
+	}
+	resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: c.maxResponseBytes}
+}
+
+// limitedBody returns ErrResponseTooLarge once more than the remaining bytes are read.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+	// read one byte more than allowed to detect bodies exceeding the limit
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), ErrResponseTooLarge
+	}
+	return n, err
+}
+
+// WithAccept replaces the default Accept header, application/json, sent with every request. Use it when the client
+// talks to an API that doesn't return JSON. Request modifiers can still override it per request.
+func (c *RestClient) WithAccept(accept string) *RestClient {
+	c.setDefaultHeader("Accept", accept)
+	return c
+}
+
+// WithUserAgent sets a default User-Agent header on every request identifying the calling service, in the form
+// "name/version (resourceName)". The version is optional and left out when empty, as is the resource name for
+// clients created with NewRestClientWithBaseURL.
+// Example:
+//
+//	client.WithUserAgent("orders-service", "1.2.0") // User-Agent: orders-service/1.2.0 (users)
+//	client.WithUserAgent("orders-service")          // User-Agent: orders-service (users)
+func (c *RestClient) WithUserAgent(name string, version ...string) *RestClient {
+	userAgent := name
+	if len(version) > 0 && version[0] != "" {
+		userAgent += "/" + version[0]
+	}
+	if c.resourceName != "" {
+		userAgent += " (" + c.resourceName + ")"
+	}
+	c.setDefaultHeader("User-Agent", userAgent)
+	return c
+}
+
+// do sends the request through the middlewares, retrying it if the client is configured to do so.
+func (c *RestClient) do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	closed := c.closed
+	c.mu.Unlock()
+	if closed {
+		return nil, ErrClientClosed
+	}
+	c.withJSONCodec(req)
+	if err := c.checkURL(req.URL); err != nil {
+		return nil, err
+	}
+	// event streams never end, so they can't be buffered to be cached or shared
+	if req.Method != http.MethodGet || req.Header.Get("Accept") == eventStreamContentType {
+		return c.doUncached(req)
+	}
+	if c.flight != nil {
+		return c.doSingleFlight(req)
+	}
+	return c.doGET(req)
+}
+
+// doGET sends a GET request using the response cache, if enabled.
+func (c *RestClient) doGET(req *http.Request) (*http.Response, error) {
+	if c.cache != nil {
+		return c.doCached(req)
+	}
+	return c.doUncached(req)
+}
+
+// doUncached authorizes and sends the request, applying the rate limit, circuit breaker and response handling.
+func (c *RestClient) doUncached(req *http.Request) (*http.Response, error) {
+	c.setIdempotencyKey(req)
+	if err := c.authorize(req); err != nil {
+		return nil, err
+	}
+	if err := c.compressRequest(req); err != nil {
+		return nil, err
+	}
+	if err := c.waitRateLimit(req); err != nil {
+		return nil, err
+	}
+	if c.breaker != nil {
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	var resp *http.Response
+	var err error
+	if c.breaker != nil {
+		resp, err = c.breaker.run(req, c.roundTripper())
+	} else {
+		resp, err = c.roundTripper()(req)
+	}
+	c.observeRequest(req, resp, time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+	decompressResponse(resp)
+	c.limitResponse(resp)
+	if err := c.decodeCharset(resp); err != nil {
+		return nil, err
+	}
+	if err := c.captureResponse(resp); err != nil {
+		return nil, err
+	}
+	if c.errOnStatus && resp.StatusCode >= 400 {
+		return nil, newStatusError(resp)
+	}
+	return resp, nil
+}
+
+// sendWithRetry sends the request, retrying it if the client is configured to do so.
+func (c *RestClient) sendWithRetry(req *http.Request) (*http.Response, error) {
+	if c.retry.shouldRetry(req) {
+		return c.doWithRetry(req)
+	}
+	return c.send(req)
+}
+
+// send performs a single attempt of the request using the configured http.Client.
+func (c *RestClient) send(req *http.Request) (*http.Response, error) {
+	req, endSpan := c.traceRequest(req)
+	if err := c.signRequest(req); err != nil {
+		endSpan(nil, err)
+		return nil, err
+	}
+	if err := c.captureRequest(req); err != nil {
+		endSpan(nil, err)
+		return nil, err
+	}
+	c.logRequest(req)
+	start := time.Now()
+	resp, err := c.sendWithTimeout(req)
+	c.logResponse(req.Context(), req, resp, err, time.Since(start))
+	endSpan(resp, err)
+	return resp, err
+}
+
+// sendWithTimeout sends the request applying the request or client timeout, if any.
+func (c *RestClient) sendWithTimeout(req *http.Request) (*http.Response, error) {
+	timeout := c.requestTimeout(req)
+	if timeout <= 0 {
+		return c.sendHTTP(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := c.sendHTTP(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// requestTimeout returns the timeout of the request set with WithRequestTimeout or the client timeout.
+func (c *RestClient) requestTimeout(req *http.Request) time.Duration {
+	if timeout, ok := req.Context().Value(requestTimeoutKey{}).(time.Duration); ok {
+		return timeout
+	}
+	return c.timeout
+}
+
+// sendHTTP sends the request with the http.Client, making sure sensitive headers are not sent to other hosts
+// when following redirects.
+func (c *RestClient) sendHTTP(req *http.Request) (*http.Response, error) {
+	// the lock guards against the transport being configured when the service address changes
+	c.mu.Lock()
+	httpClient := *c.httpClient()
+	c.mu.Unlock()
+	httpClient.CheckRedirect = stripSensitiveHeadersOnRedirect(httpClient.CheckRedirect)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the request context when the response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
 // init initializes the RestClient with the provided ConfigProvider.
+// Calling it again, e.g. when the configuration is reloaded, resolves the service address again and replaces the BaseURL.
+// If the service address can't be resolved the error is recorded, see Err, and the client is left uninitialized.
 func (c *RestClient) init(provider providers.ConfigProvider) {
+	baseURL, err := c.resolveBaseURL(provider)
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.provider = provider
+	c.initErr = err
+	if err != nil {
+		c.logf(slog.LevelWarn, "REST client for %s not initialized: %s", c.resourceName, err)
+		return
+	}
+	c.setBaseURL(baseURL)
+
 	if c.ready {
-		panic("Client already initialized")
+		c.logf(slog.LevelInfo, "REST client updated for %s --> %s", c.resourceName, baseURL)
+		return
+	}
+	c.logf(slog.LevelInfo, "REST client ready for %s --> %s", c.resourceName, baseURL)
+	c.ready = true
+	close(c.readyChan())
+}
+
+// IsReady returns true once the client has been initialized and the BaseURL is resolved.
+func (c *RestClient) IsReady() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ready
+}
+
+// WaitReady blocks until the client has been initialized or the context is done, in which case the context error
+// is returned.
+func (c *RestClient) WaitReady(ctx context.Context) error {
+	c.mu.Lock()
+	readyCh := c.readyChan()
+	c.mu.Unlock()
+
+	select {
+	case <-readyCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// readyChan returns the channel that is closed when the client is ready, the lock must be held.
+func (c *RestClient) readyChan() chan struct{} {
+	if c.readyCh == nil {
+		c.readyCh = make(chan struct{})
 	}
+	return c.readyCh
+}
+
+// resolveBaseURL gets the service address of the resource from the provider.
+func (c *RestClient) resolveBaseURL(provider providers.ConfigProvider) (string, error) {
+	c.mu.Lock()
+	serviceType := c.serviceType
+	c.mu.Unlock()
 
 	service, err := provider.GetServiceAddress(c.resourceName, serviceType)
 	if err != nil {
-		panic(fmt.Sprintf("Error getting service address for %s: %s", c.resourceName, err))
+		return "", fmt.Errorf("Error getting service address for %s: %s", c.resourceName, err)
 	}
 
-	c.BaseURL = strings.ToLower(service)
+	if strings.TrimSpace(service) == "" {
+		return "", fmt.Errorf("empty service address for %s", c.resourceName)
+	}
 
-	c.BaseURL = strings.TrimSuffix(c.BaseURL, "/")
+	baseURL, err := normalizeBaseURL(service)
+	if err != nil {
+		return "", fmt.Errorf("invalid service address for %s: %w", c.resourceName, err)
+	}
+	return baseURL, nil
+}
 
-	log.Printf("REST client ready for %s --> %s\n", c.resourceName, c.BaseURL)
-	c.ready = true
+// normalizeBaseURL validates the address is an absolute URL, defaulting the scheme to http when it is missing.
+// A unix:// address with the path of a unix domain socket is returned as is.
+// The scheme and host are lowercased, the path and query are case-sensitive and kept as is.
+func normalizeBaseURL(address string) (string, error) {
+	address = strings.TrimSpace(address)
+	if socketPath, ok := unixSocketPath(address); ok {
+		if socketPath == "" {
+			return "", fmt.Errorf("missing socket path in %q", address)
+		}
+		return unixScheme + socketPath, nil
+	}
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", err
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("missing host in %q", address)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	return strings.TrimSuffix(u.String(), "/"), nil
+}
+
+// Err returns the error of the last attempt to initialize the client, or nil if it succeeded.
+func (c *RestClient) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.initErr
+}
+
+// checkURL returns an error if the request URL is not absolute, which happens when it was resolved before the
+// client was initialized or when the service address could not be resolved.
+func (c *RestClient) checkURL(u *url.URL) error {
+	if u.IsAbs() && u.Host != "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	ready, initErr := c.ready, c.initErr
+	c.mu.Unlock()
+
+	switch {
+	case !ready && initErr != nil:
+		return fmt.Errorf("invalid request URL %q: %w: %w", u, ErrClientNotReady, initErr)
+	case !ready:
+		return fmt.Errorf("invalid request URL %q: %w", u, ErrClientNotReady)
+	}
+	return fmt.Errorf("invalid request URL %q: URL must be absolute", u)
+}
+
+// Refresh resolves the service address again using the ConfigProvider the client was initialized with and updates
+// the BaseURL if it changed. Requests that are in flight keep using the address they were created with.
+func (c *RestClient) Refresh() error {
+	c.mu.Lock()
+	provider := c.provider
+	c.mu.Unlock()
+	if provider == nil {
+		return fmt.Errorf("REST client for %s is not initialized", c.resourceName)
+	}
+
+	baseURL, err := c.resolveBaseURL(provider)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if baseURL != c.address() {
+		c.setBaseURL(baseURL)
+		c.logf(slog.LevelInfo, "REST client updated for %s --> %s", c.resourceName, baseURL)
+	}
+	return nil
+}
+
+// WatchServiceAddress calls Refresh at the given interval until the context is cancelled, so the client follows
+// changes of the service address at runtime. Errors are logged and the previous BaseURL is kept. An interval that is
+// not positive is logged and returns immediately.
+// Example:
+//
+//	go client.WatchServiceAddress(ctx, 30*time.Second)
+func (c *RestClient) WatchServiceAddress(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		c.logf(slog.LevelWarn, "Invalid interval %s to watch the service address of %s", interval, c.resourceName)
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Refresh(); err != nil {
+				c.logf(slog.LevelWarn, "Error refreshing REST client for %s: %s", c.resourceName, err)
+			}
+		}
+	}
+}
+
+// Close closes the idle connections of the client and makes all further requests fail with ErrClientClosed.
+// Requests in flight are not aborted, use WithBaseContext for that. When the client uses http.DefaultClient the idle
+// connections of http.DefaultTransport are closed, which are shared with other clients.
+func (c *RestClient) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	c.httpClient().CloseIdleConnections()
+	return nil
+}
+
+// baseURL returns the current BaseURL while holding the lock.
+func (c *RestClient) baseURL() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.BaseURL
 }
 
 // ResolveURL resolves the path to a full URL by prepending the BaseURL.
+// The args are inserted as is, use ResolveURLEscaped when they may contain characters like / or spaces.
 func (c *RestClient) ResolveURL(path string, args ...interface{}) string {
-	return fmt.Sprintf("%s%s", c.BaseURL, fmt.Sprintf(path, args...))
+	return fmt.Sprintf("%s%s", c.baseURL(), fmt.Sprintf(path, args...))
+}
+
+// ResolveURLEscaped resolves the path to a full URL by prepending the BaseURL, escaping string args using
+// url.PathEscape before they are inserted. Other args, like numbers, are inserted as is.
+// Example:
+//
+//	client.ResolveURLEscaped("/api/v1/files/%s", "a/b") // <BaseURL>/api/v1/files/a%2Fb
+func (c *RestClient) ResolveURLEscaped(path string, args ...interface{}) string {
+	escaped := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			escaped[i] = url.PathEscape(v)
+		case fmt.Stringer:
+			escaped[i] = url.PathEscape(v.String())
+		default:
+			escaped[i] = arg
+		}
+	}
+	return c.ResolveURL(path, escaped...)
 }
 
+// QueryParameterRequestModifier returns a request modifier that adds the fields of the struct as query parameters,
+// see StructToQueryParams. Parameters already present on the URL are kept unless the struct sets the same key, in which
+// case they are replaced.
+// A map[string]string, map[string][]string or url.Values can be passed instead of a struct when the parameters are
+// only known at runtime.
 func QueryParameterRequestModifier(queryParams any) func(req *http.Request) {
 	return func(req *http.Request) {
-		params, err := StructToQueryParams(queryParams)
+		params, err := toQueryValues(queryParams)
 		if err != nil {
 			panic(fmt.Errorf("error creating query parameters: %s", err))
 		}
-		req.URL.RawQuery += params
+		query := req.URL.Query()
+		for key, values := range params {
+			query[key] = values
+		}
+		req.URL.RawQuery = query.Encode()
 	}
 }
 
-// GET performs a GET request to the specified URL. The requestModifier can be used to modify the request before it is sent.
+// WithQuery returns a request modifier that adds the query parameters from a struct, map or url.Values to the request,
+// it is a shorter name for QueryParameterRequestModifier.
 // Example:
 //
-//	response, err := client.GET(client.ResolveURL("/api/v1/users/%s", userID), func(req *http.Request) {
-//		req.Header.Set("Authorization", "Bearer "+token)
+//	response, err := client.GET(client.ResolveURL("/api/v1/users"), WithQuery(UserFilter{Name: "john"}))
+func WithQuery(queryParams any) func(req *http.Request) {
+	return QueryParameterRequestModifier(queryParams)
+}
+
+// Request performs a request with an arbitrary method to the specified URL. A non-nil body is sent as JSON, a []byte,
+// json.RawMessage or io.Reader is sent as is. A nil body, including a nil pointer, []byte or json.RawMessage, sends an
+// empty body without content type, while nil slices and maps are sent as JSON null.
+// The requestModifier can be used to modify the request before it is sent.
+// Example:
+//
+//	response, err := client.Request("PROPFIND", client.ResolveURL("/api/v1/files/%s", fileID), nil, func(req *http.Request) {
+//		req.Header.Set("Depth", "1")
 //	})
-func (c *RestClient) GET(url string, requestModifier ...func(req *http.Request)) (*http.Response, error) {
-	req, err := http.NewRequest("GET", url, nil)
+func (c *RestClient) Request(method string, url string, body any, requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	if isNilBody(body) {
+		return c.doRaw(method, url, "", nil, -1, requestModifier)
+	}
+	reader, size, err := c.jsonBody(body)
 	if err != nil {
 		return nil, err
 	}
+	return c.doRaw(method, url, "application/json", reader, size, requestModifier)
+}
+
+// isNilBody returns true if body is nil, a nil pointer or a nil raw body. Other nil values like slices and maps are
+// sent as JSON null.
+func isNilBody(body any) bool {
+	switch b := body.(type) {
+	case nil:
+		return true
+	case []byte:
+		return b == nil
+	case json.RawMessage:
+		return b == nil
+	}
+	v := reflect.ValueOf(body)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// jsonBody returns a reader with the JSON encoding of body and its length, or -1 if unknown. A []byte,
+// json.RawMessage or io.Reader is assumed to be JSON already and is sent as is.
+func (c *RestClient) jsonBody(body any) (io.Reader, int64, error) {
+	switch b := body.(type) {
+	case []byte:
+		return bytes.NewReader(b), int64(len(b)), nil
+	case json.RawMessage:
+		return bytes.NewReader(b), int64(len(b)), nil
+	case io.Reader:
+		return b, -1, nil
+	}
+	bodyData, err := c.jsonCodec().marshal(body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bytes.NewReader(bodyData), int64(len(bodyData)), nil
+}
+
+// doRaw creates a request with the given body and content type, applies the modifiers and sends it.
+// size is the length of the body or -1 if unknown, in which case it is taken from the body if it has a Len method.
+// A size of 0 with a non-nil body is treated as unknown, so the body is never dropped.
+func (c *RestClient) doRaw(method string, url string, contentType string, body io.Reader, size int64, requestModifier []func(req *http.Request)) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(c.baseContext(), method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 && body != nil {
+		// like net/http, a zero length with a body means the length is unknown
+		size = -1
+	}
+	if size < 0 && req.ContentLength == 0 {
+		if lenBody, ok := body.(interface{ Len() int }); ok && lenBody.Len() == 0 {
+			req.Body = http.NoBody
+			req.GetBody = func() (io.ReadCloser, error) { return http.NoBody, nil }
+		} else if ok {
+			size = int64(lenBody.Len())
+		}
+	}
+	if size >= 0 {
+		req.ContentLength = size
+	}
+	req.Header.Set("Accept", defaultAccept)
+	for key, values := range c.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 	for _, modifier := range requestModifier {
 		modifier(req)
 	}
-	return http.DefaultClient.Do(req)
+	return c.do(req)
+}
+
+// GET performs a GET request to the specified URL. The requestModifier can be used to modify the request before it is sent.
+// Example:
+//
+//	response, err := client.GET(client.ResolveURL("/api/v1/users/%s", userID), func(req *http.Request) {
+//		req.Header.Set("Authorization", "Bearer "+token)
+//	})
+func (c *RestClient) GET(url string, requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	return c.Request("GET", url, nil, requestModifier...)
+}
+
+// GETAndHandle performs a GET request and passes the response to the handler. The response body is drained and
+// closed after the handler returns, even if it panics, so the handler doesn't need to close it.
+// Example:
+//
+//	err := client.GETAndHandle(client.ResolveURL("/api/v1/users/%s", userID), func(response *http.Response) error {
+//		return json.NewDecoder(response.Body).Decode(&user)
+//	})
+func (c *RestClient) GETAndHandle(url string, handler func(resp *http.Response) error, requestModifier ...func(req *http.Request)) error {
+	resp, err := c.GET(url, requestModifier...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+	return handler(resp)
 }
 
 // DELETE performs a DELETE request to the specified URL. The requestModifier can be used to modify the request before it is sent.
@@ -106,14 +854,7 @@ func (c *RestClient) GET(url string, requestModifier ...func(req *http.Request))
 //		req.Header.Set("Authorization", "Bearer "+token)
 //	})
 func (c *RestClient) DELETE(url string, requestModifier ...func(req *http.Request)) (*http.Response, error) {
-	req, err := http.NewRequest("DELETE", url, nil)
-	if err != nil {
-		return nil, err
-	}
-	for _, modifier := range requestModifier {
-		modifier(req)
-	}
-	return http.DefaultClient.Do(req)
+	return c.Request("DELETE", url, nil, requestModifier...)
 }
 
 // PUT performs a PUT request to the specified URL. The requestModifier can be used to modify the request before it is sent.
@@ -123,41 +864,83 @@ func (c *RestClient) DELETE(url string, requestModifier ...func(req *http.Reques
 //		req.Header.Set("Authorization", "Bearer "+token)
 //	})
 func (c *RestClient) PUT(url string, body any, requestModifier ...func(req *http.Request)) (*http.Response, error) {
-	bodyData, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
+	if isNilBody(body) {
+		return c.PUTRaw(url, "", nil, requestModifier...)
 	}
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(bodyData))
+	reader, size, err := c.jsonBody(body)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	for _, modifier := range requestModifier {
-		modifier(req)
-	}
-	return http.DefaultClient.Do(req)
+	return c.PUTStream(url, "application/json", reader, size, requestModifier...)
+}
+
+// PUTRaw performs a PUT request to the specified URL sending the body as is with the given content type.
+// Example:
+//
+//	response, err := client.PUTRaw(client.ResolveURL("/api/v1/users/%s", userID), "text/plain", strings.NewReader("john"))
+func (c *RestClient) PUTRaw(url string, contentType string, body io.Reader, requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	return c.doRaw("PUT", url, contentType, body, -1, requestModifier)
+}
+
+// PUTStream performs a PUT request to the specified URL streaming the body without buffering it in memory.
+// size is the length of the body used for the Content-Length header, use -1 or 0 if unknown to send it chunked.
+// Example:
+//
+//	file, err := os.Open("upload.bin")
+//	...
+//	response, err := client.PUTStream(client.ResolveURL("/api/v1/files/%s", fileID), "application/octet-stream", file, fileSize)
+func (c *RestClient) PUTStream(url string, contentType string, body io.Reader, size int64, requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	return c.doRaw("PUT", url, contentType, body, size, requestModifier)
 }
 
 // POST performs a POST request to the specified URL. The requestModifier can be used to modify the request before it is sent.
+// The body is encoded as JSON, a []byte, json.RawMessage or io.Reader holding serialized JSON is sent as is.
+// A nil body or nil pointer sends an empty body without a Content-Type, the same applies to PUT and PATCH. Nil slices
+// and maps are sent as JSON null.
 // Example:
 //
 //	response, err := client.POST(client.ResolveURL("/api/v1/users/%s", userID), user, func(req *http.Request) {
 //		req.Header.Set("Authorization", "Bearer "+token)
 //	})
 func (c *RestClient) POST(url string, body any, requestModifier ...func(req *http.Request)) (*http.Response, error) {
-	bodyData, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
+	if isNilBody(body) {
+		return c.POSTRaw(url, "", nil, requestModifier...)
 	}
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyData))
+	reader, size, err := c.jsonBody(body)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	for _, modifier := range requestModifier {
-		modifier(req)
-	}
-	return http.DefaultClient.Do(req)
+	return c.POSTStream(url, "application/json", reader, size, requestModifier...)
+}
+
+// POSTRaw performs a POST request to the specified URL sending the body as is with the given content type.
+// Example:
+//
+//	response, err := client.POSTRaw(client.ResolveURL("/api/v1/users/%s", userID), "text/plain", strings.NewReader("john"))
+func (c *RestClient) POSTRaw(url string, contentType string, body io.Reader, requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	return c.doRaw("POST", url, contentType, body, -1, requestModifier)
+}
+
+// POSTStream performs a POST request to the specified URL streaming the body without buffering it in memory.
+// size is the length of the body used for the Content-Length header, use -1 or 0 if unknown to send it chunked.
+// Example:
+//
+//	file, err := os.Open("upload.bin")
+//	...
+//	response, err := client.POSTStream(client.ResolveURL("/api/v1/files/%s", fileID), "application/octet-stream", file, fileSize)
+func (c *RestClient) POSTStream(url string, contentType string, body io.Reader, size int64, requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	return c.doRaw("POST", url, contentType, body, size, requestModifier)
+}
+
+// POSTForm performs a POST request to the specified URL sending the values as application/x-www-form-urlencoded.
+// Example:
+//
+//	response, err := client.POSTForm(client.ResolveURL("/oauth/token"), url.Values{
+//		"grant_type": {"client_credentials"},
+//		"scope":      {"users:read"},
+//	})
+func (c *RestClient) POSTForm(url string, values url.Values, requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	return c.POSTRaw(url, "application/x-www-form-urlencoded", strings.NewReader(values.Encode()), requestModifier...)
 }
 
 // PATCH performs a PATCH request to the specified URL. The requestModifier can be used to modify the request before it is sent.
@@ -167,19 +950,33 @@ func (c *RestClient) POST(url string, body any, requestModifier ...func(req *htt
 //		req.Header.Set("Authorization", "Bearer "+token)
 //	})
 func (c *RestClient) PATCH(url string, body any, requestModifier ...func(req *http.Request)) (*http.Response, error) {
-	bodyData, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
+	if isNilBody(body) {
+		return c.PATCHRaw(url, "", nil, requestModifier...)
 	}
-	req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(bodyData))
+	reader, size, err := c.jsonBody(body)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	for _, modifier := range requestModifier {
-		modifier(req)
-	}
-	return http.DefaultClient.Do(req)
+	return c.PATCHStream(url, "application/json", reader, size, requestModifier...)
+}
+
+// PATCHRaw performs a PATCH request to the specified URL sending the body as is with the given content type.
+// Example:
+//
+//	response, err := client.PATCHRaw(client.ResolveURL("/api/v1/users/%s", userID), "text/plain", strings.NewReader("john"))
+func (c *RestClient) PATCHRaw(url string, contentType string, body io.Reader, requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	return c.doRaw("PATCH", url, contentType, body, -1, requestModifier)
+}
+
+// PATCHStream performs a PATCH request to the specified URL streaming the body without buffering it in memory.
+// size is the length of the body used for the Content-Length header, use -1 or 0 if unknown to send it chunked.
+// Example:
+//
+//	file, err := os.Open("upload.bin")
+//	...
+//	response, err := client.PATCHStream(client.ResolveURL("/api/v1/files/%s", fileID), "application/octet-stream", file, fileSize)
+func (c *RestClient) PATCHStream(url string, contentType string, body io.Reader, size int64, requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	return c.doRaw("PATCH", url, contentType, body, size, requestModifier)
 }
 
 // HEAD performs a HEAD request to the specified URL. The requestModifier can be used to modify the request before it is sent.
@@ -189,12 +986,14 @@ func (c *RestClient) PATCH(url string, body any, requestModifier ...func(req *ht
 //		req.Header.Set("Authorization", "Bearer "+token)
 //	})
 func (c *RestClient) HEAD(url string, requestModifier ...func(req *http.Request)) (*http.Response, error) {
-	req, err := http.NewRequest("HEAD", url, nil)
-	if err != nil {
-		return nil, err
-	}
-	for _, modifier := range requestModifier {
-		modifier(req)
-	}
-	return http.DefaultClient.Do(req)
+	return c.Request("HEAD", url, nil, requestModifier...)
+}
+
+// OPTIONS performs an OPTIONS request to the specified URL. The requestModifier can be used to modify the request before it is sent.
+// Example:
+//
+//	response, err := client.OPTIONS(client.ResolveURL("/api/v1/users"))
+//	allowed := response.Header.Get("Allow")
+func (c *RestClient) OPTIONS(url string, requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	return c.Request("OPTIONS", url, nil, requestModifier...)
 }
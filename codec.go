@@ -0,0 +1,83 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// jsonCodec encodes request bodies and decodes response bodies.
+type jsonCodec struct {
+	marshal   func(v any) ([]byte, error)
+	unmarshal func(data []byte, v any) error
+}
+
+var (
+	defaultJSONCodec = &jsonCodec{marshal: json.Marshal, unmarshal: json.Unmarshal}
+	strictJSONCodec  = &jsonCodec{marshal: json.Marshal, unmarshal: unmarshalStrict}
+)
+
+// jsonCodecKey is the context key of the codec of the client that sent a request.
+type jsonCodecKey struct{}
+
+// WithJSONCodec replaces encoding/json for encoding request bodies and decoding responses with Decode and the decode
+// helpers, e.g. to use a faster JSON library.
+// Example:
+//
+//	client := NewRestClient("users", true).WithJSONCodec(sonic.Marshal, sonic.Unmarshal)
+func (c *RestClient) WithJSONCodec(marshal func(v any) ([]byte, error), unmarshal func(data []byte, v any) error) *RestClient {
+	c.codec = &jsonCodec{marshal: marshal, unmarshal: unmarshal}
+	return c
+}
+
+// WithStrictDecoding makes Decode and the decode helpers fail when a response contains fields that are not in the
+// type it is decoded into, e.g. to detect changes of the API contract in tests. It has no effect when a codec is set
+// with WithJSONCodec.
+func (c *RestClient) WithStrictDecoding() *RestClient {
+	c.strictDecoding = true
+	return c
+}
+
+// jsonCodec returns the codec of the client.
+func (c *RestClient) jsonCodec() *jsonCodec {
+	switch {
+	case c.codec != nil:
+		return c.codec
+	case c.strictDecoding:
+		return strictJSONCodec
+	}
+	return defaultJSONCodec
+}
+
+// withJSONCodec stores the codec of the client in the request context, so Decode can use it for the response.
+func (c *RestClient) withJSONCodec(req *http.Request) {
+	if codec := c.jsonCodec(); codec != defaultJSONCodec {
+		*req = *req.WithContext(context.WithValue(req.Context(), jsonCodecKey{}, codec))
+	}
+}
+
+// unmarshalStrict works like json.Unmarshal but returns an error for fields not present in v.
+func unmarshalStrict(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("invalid data after top-level value")
+	}
+	return nil
+}
+
+// responseJSONCodec returns the codec of the client that sent the request of the response.
+func responseJSONCodec(resp *http.Response) *jsonCodec {
+	if resp.Request != nil {
+		if codec, ok := resp.Request.Context().Value(jsonCodecKey{}).(*jsonCodec); ok {
+			return codec
+		}
+	}
+	return defaultJSONCodec
+}
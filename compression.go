@@ -0,0 +1,101 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// WithRequestCompression gzip compresses request bodies larger than threshold bytes and sets the
+// Content-Encoding: gzip header. Only bodies held in memory, like the JSON bodies of PUT, POST and PATCH, are
+// compressed, streamed bodies are sent as is. Smaller bodies are sent uncompressed to avoid the overhead.
+func (c *RestClient) WithRequestCompression(threshold int64) *RestClient {
+	c.compressThreshold = threshold
+	c.compress = true
+	return c
+}
+
+// compressRequest replaces the request body with its gzip compressed version if it exceeds the threshold.
+func (c *RestClient) compressRequest(req *http.Request) error {
+	if !c.compress || req.GetBody == nil || req.ContentLength <= c.compressThreshold {
+		return nil
+	}
+	if req.Header.Get("Content-Encoding") != "" {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := io.Copy(writer, body); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	compressed := buf.Bytes()
+	req.Body = io.NopCloser(bytes.NewReader(compressed))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	req.ContentLength = int64(len(compressed))
+	req.Header.Set("Content-Encoding", "gzip")
+	return nil
+}
+
+// decompressResponse wraps the response body in a decompressing reader when the server sent a gzip or deflate
+// encoded body that the transport didn't decompress, e.g. because Accept-Encoding was set explicitly.
+func decompressResponse(resp *http.Response) {
+	if resp.Uncompressed || resp.Body == nil || resp.Body == http.NoBody {
+		return
+	}
+
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding != "gzip" && encoding != "deflate" {
+		return
+	}
+
+	resp.Body = &decompressingBody{body: resp.Body, encoding: encoding}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// decompressingBody decompresses the body lazily so empty bodies, e.g. of HEAD requests, don't fail.
+type decompressingBody struct {
+	body     io.ReadCloser
+	encoding string
+	reader   io.ReadCloser
+	err      error
+}
+
+func (b *decompressingBody) Read(p []byte) (int, error) {
+	if b.reader == nil && b.err == nil {
+		if b.encoding == "gzip" {
+			b.reader, b.err = gzip.NewReader(b.body)
+		} else {
+			b.reader, b.err = zlib.NewReader(b.body)
+		}
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.reader.Read(p)
+}
+
+func (b *decompressingBody) Close() error {
+	if b.reader != nil {
+		_ = b.reader.Close()
+	}
+	return b.body.Close()
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRequestCompression(t *testing.T) {
+	var encoding, body string
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding = r.Header.Get("Content-Encoding")
+			var reader io.Reader = r.Body
+			if encoding == "gzip" {
+				gz, err := gzip.NewReader(r.Body)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				reader = gz
+			}
+			data, _ := io.ReadAll(reader)
+			body = string(data)
+		}),
+	)
+	defer srv.Close()
+
+	client := NewRestClient("resource", false).WithRequestCompression(100)
+
+	t.Run("should compress bodies above the threshold", func(t *testing.T) {
+		large := map[string]string{"data": strings.Repeat("a", 200)}
+		_, err := client.POST(srv.URL, large)
+		assert.Nil(t, err)
+		assert.Equal(t, "gzip", encoding)
+		assert.Equal(t, `{"data":"`+strings.Repeat("a", 200)+`"}`, body)
+	})
+	t.Run("should not compress small bodies", func(t *testing.T) {
+		_, err := client.POST(srv.URL, map[string]string{"data": "a"})
+		assert.Nil(t, err)
+		assert.Empty(t, encoding)
+		assert.Equal(t, `{"data":"a"}`, body)
+	})
+	t.Run("should not compress streamed bodies", func(t *testing.T) {
+		_, err := client.POSTStream(srv.URL, "text/plain", io.MultiReader(strings.NewReader(strings.Repeat("a", 200))), -1)
+		assert.Nil(t, err)
+		assert.Empty(t, encoding)
+	})
+}
+
+func TestDecompressResponse(t *testing.T) {
+	compress := func(encoding string, data string) []byte {
+		var buf bytes.Buffer
+		var writer io.WriteCloser
+		if encoding == "gzip" {
+			writer = gzip.NewWriter(&buf)
+		} else {
+			writer = zlib.NewWriter(&buf)
+		}
+		_, _ = writer.Write([]byte(data))
+		_ = writer.Close()
+		return buf.Bytes()
+	}
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding := r.URL.Query().Get("encoding")
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Encoding", encoding)
+			if r.Method == http.MethodHead {
+				return
+			}
+			_, _ = w.Write(compress(encoding, `{"name":"john"}`))
+		}),
+	)
+	defer srv.Close()
+
+	// setting Accept-Encoding explicitly disables the transparent decompression of the transport
+	acceptEncoding := func(req *http.Request) {
+		req.Header.Set("Accept-Encoding", "gzip, deflate")
+	}
+	client := NewRestClient("resource", false)
+
+	for _, encoding := range []string{"gzip", "deflate"} {
+		t.Run("should decompress "+encoding+" responses", func(t *testing.T) {
+			resp, err := client.GET(srv.URL+"?encoding="+encoding, acceptEncoding)
+			assert.Nil(t, err)
+			assert.Empty(t, resp.Header.Get("Content-Encoding"))
+			got, err := Decode[map[string]string](resp)
+			assert.Nil(t, err)
+			assert.Equal(t, map[string]string{"name": "john"}, got)
+		})
+	}
+	t.Run("should handle empty compressed responses", func(t *testing.T) {
+		resp, err := client.HEAD(srv.URL+"?encoding=gzip", acceptEncoding)
+		assert.Nil(t, err)
+		body, err := io.ReadAll(resp.Body)
+		assert.Nil(t, err)
+		assert.Empty(t, body)
+		assert.Nil(t, resp.Body.Close())
+	})
+}
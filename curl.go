@@ -0,0 +1,91 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// DumpAsCurl renders the request as a curl command that can be copied to a shell to reproduce it. The values of
+// DefaultRedactedHeaders are replaced with "***". The body is read using GetBody when set, otherwise it is read
+// into memory and replaced so the request can still be sent.
+// Example:
+//
+//	response, err := client.GET(url, func(req *http.Request) {
+//		command, _ := DumpAsCurl(req)
+//		log.Println(command)
+//	})
+func DumpAsCurl(req *http.Request) (string, error) {
+	return dumpAsCurl(req, redactHeaderValues(req.Header, DefaultRedactedHeaders))
+}
+
+// WithCurlLogging adds the request as a curl command to the debug log of every request, see WithLogger and
+// DumpAsCurl. Sensitive headers are redacted, see WithRedactedHeaders.
+func (c *RestClient) WithCurlLogging() *RestClient {
+	c.logCurl = true
+	return c
+}
+
+// dumpAsCurl renders the request as a curl command using the given headers.
+func dumpAsCurl(req *http.Request, headers http.Header) (string, error) {
+	var command strings.Builder
+	command.WriteString("curl -X ")
+	command.WriteString(req.Method)
+	command.WriteString(" ")
+	command.WriteString(shellQuote(req.URL.String()))
+
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range headers[key] {
+			command.WriteString(" -H ")
+			command.WriteString(shellQuote(key + ": " + value))
+		}
+	}
+
+	body, err := peekBody(req)
+	if err != nil {
+		return "", err
+	}
+	if len(body) > 0 {
+		command.WriteString(" --data-binary ")
+		command.WriteString(shellQuote(string(body)))
+	}
+	return command.String(), nil
+}
+
+// peekBody returns the request body without consuming it.
+func peekBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return data, nil
+}
+
+// shellQuote quotes the value for a POSIX shell using single quotes.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
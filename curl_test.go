@@ -0,0 +1,59 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDumpAsCurl(t *testing.T) {
+	t.Run("should render method, url, headers and body", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "http://localhost/users?q=a", strings.NewReader(`{"name":"o'neil"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer secret")
+
+		command, err := DumpAsCurl(req)
+		assert.Nil(t, err)
+		assert.Equal(t, `curl -X POST 'http://localhost/users?q=a' -H 'Authorization: ***' -H 'Content-Type: application/json' --data-binary '{"name":"o'\''neil"}'`, command)
+
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, `{"name":"o'neil"}`, string(body))
+	})
+	t.Run("should keep a body without GetBody readable", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPut, "http://localhost/users", io.NopCloser(strings.NewReader("data")))
+
+		command, err := DumpAsCurl(req)
+		assert.Nil(t, err)
+		assert.Equal(t, `curl -X PUT 'http://localhost/users' --data-binary 'data'`, command)
+
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, "data", string(body))
+	})
+	t.Run("should log the curl command at debug level", func(t *testing.T) {
+		var got string
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, _ := io.ReadAll(r.Body)
+				got = string(data)
+			}),
+		)
+		defer srv.Close()
+
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		client := NewRestClient("resource", false).WithLogger(logger).WithCurlLogging()
+		_, err := client.POST(srv.URL, map[string]string{"name": "john"}, BearerAuthModifier("secret"))
+		assert.Nil(t, err)
+
+		assert.Equal(t, `{"name":"john"}`, got)
+		assert.Contains(t, buf.String(), "curl -X POST")
+		assert.Contains(t, buf.String(), "Authorization: ***")
+		assert.NotContains(t, buf.String(), "secret")
+	})
+}
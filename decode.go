@@ -0,0 +1,144 @@
+package client
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Decode reads the response body and unmarshals the JSON content into a value of type T. The body is always closed.
+// An *HTTPError is returned if the status code is not 2xx and a *DecodeError if the response content type is not JSON,
+// with the beginning of the body in its message, declares a charset other than UTF-8, see WithCharsetDecoding, or the
+// body can not be unmarshalled. ErrNotModified is returned for a 304 Not Modified response, and the zero value of T
+// for a 204 No Content response or an empty body.
+// Requests ask for JSON using the Accept header by default, see WithAccept. The body is unmarshalled with the codec of
+// the client that sent the request, see WithJSONCodec.
+// Example:
+//
+//	response, err := client.GET(client.ResolveURL("/api/v1/users/%s", userID))
+//	if err != nil {
+//		return err
+//	}
+//	user, err := Decode[User](response)
+func Decode[T any](resp *http.Response) (T, error) {
+	var result T
+	if resp.StatusCode == http.StatusNotModified {
+		_ = resp.Body.Close()
+		return result, ErrNotModified
+	}
+	if !IsSuccess(resp) {
+		return result, newStatusError(resp)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNoContent {
+		return result, nil
+	}
+	// the content length of responses created by custom transports is often not set, so check for an empty body
+	reader := bufio.NewReader(resp.Body)
+	if _, err := reader.Peek(1); errors.Is(err, io.EOF) {
+		return result, nil
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if !isJSONContentType(contentType) {
+		return result, newContentTypeError(contentType, "JSON", reader)
+	}
+	if err := checkUTF8Charset(contentType); err != nil {
+		return result, err
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return result, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if err := responseJSONCodec(resp).unmarshal(body, &result); err != nil {
+		return result, &DecodeError{ContentType: contentType, Body: body, Err: err}
+	}
+	return result, nil
+}
+
+// maxBodySnippet is the number of bytes of the body included in the error for an unexpected content type.
+const maxBodySnippet = 256
+
+// newContentTypeError returns a DecodeError for an unexpected content type, including the beginning of the body to
+// help identify it, e.g. an HTML error or login page.
+func newContentTypeError(contentType string, expected string, body io.Reader) *DecodeError {
+	snippet, _ := io.ReadAll(io.LimitReader(body, maxBodySnippet))
+	err := fmt.Errorf("unexpected content type %q, expected %s", contentType, expected)
+	if text := strings.TrimSpace(string(snippet)); text != "" {
+		err = fmt.Errorf("%w, body starts with %q", err, text)
+	}
+	return &DecodeError{ContentType: contentType, Body: snippet, Err: err}
+}
+
+// isJSONContentType returns true if the content type is application/json or a +json media type.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// Get performs a GET request and decodes the JSON response into a value of type T, see Decode.
+// Example:
+//
+//	user, err := Get[User](client, client.ResolveURL("/api/v1/users/%s", userID))
+func Get[T any](c *RestClient, url string, requestModifier ...func(req *http.Request)) (T, error) {
+	return decodeResponse[T](c.GET(url, requestModifier...))
+}
+
+// Post performs a POST request with the body as JSON and decodes the JSON response into a value of type T, see Decode.
+// Example:
+//
+//	created, err := Post[User](client, client.ResolveURL("/api/v1/users"), user)
+func Post[T any](c *RestClient, url string, body any, requestModifier ...func(req *http.Request)) (T, error) {
+	return decodeResponse[T](c.POST(url, body, requestModifier...))
+}
+
+// PostInto performs a POST request with the body as JSON and decodes the JSON response into out, see Post.
+// out is left unchanged when an error is returned.
+// Example:
+//
+//	var created User
+//	if err := PostInto(client, client.ResolveURL("/api/v1/users"), user, &created); err != nil {
+//		return err
+//	}
+func PostInto[T any](c *RestClient, url string, body any, out *T, requestModifier ...func(req *http.Request)) error {
+	result, err := Post[T](c, url, body, requestModifier...)
+	if err != nil {
+		return err
+	}
+	*out = result
+	return nil
+}
+
+// Put performs a PUT request with the body as JSON and decodes the JSON response into a value of type T, see Decode.
+// Example:
+//
+//	updated, err := Put[User](client, client.ResolveURL("/api/v1/users/%s", userID), user)
+func Put[T any](c *RestClient, url string, body any, requestModifier ...func(req *http.Request)) (T, error) {
+	return decodeResponse[T](c.PUT(url, body, requestModifier...))
+}
+
+// Patch performs a PATCH request with the body as JSON and decodes the JSON response into a value of type T, see Decode.
+// Example:
+//
+//	updated, err := Patch[User](client, client.ResolveURL("/api/v1/users/%s", userID), changes)
+func Patch[T any](c *RestClient, url string, body any, requestModifier ...func(req *http.Request)) (T, error) {
+	return decodeResponse[T](c.PATCH(url, body, requestModifier...))
+}
+
+// decodeResponse decodes the response of a request unless the request failed.
+func decodeResponse[T any](resp *http.Response, err error) (T, error) {
+	if err != nil {
+		var result T
+		return result, err
+	}
+	return Decode[T](resp)
+}
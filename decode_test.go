@@ -0,0 +1,348 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	t.Run("should decode a JSON response", func(t *testing.T) {
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				_, _ = w.Write([]byte(`{"name":"john"}`))
+			}),
+		)
+		defer srv.Close()
+
+		resp, err := NewRestClient("resource", false).GET(srv.URL)
+		assert.Nil(t, err)
+		got, err := Decode[user](resp)
+		assert.Nil(t, err)
+		assert.Equal(t, user{Name: "john"}, got)
+	})
+	t.Run("should return error for non 2xx status", func(t *testing.T) {
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{}`))
+			}),
+		)
+		defer srv.Close()
+
+		resp, err := NewRestClient("resource", false).GET(srv.URL)
+		assert.Nil(t, err)
+		_, err = Decode[user](resp)
+		var httpErr *HTTPError
+		assert.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+	})
+	t.Run("should return error for non JSON content type", func(t *testing.T) {
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				_, _ = w.Write([]byte(`<html></html>`))
+			}),
+		)
+		defer srv.Close()
+
+		resp, err := NewRestClient("resource", false).GET(srv.URL)
+		assert.Nil(t, err)
+		_, err = Decode[user](resp)
+		assert.Error(t, err)
+	})
+	t.Run("should include a snippet of the body for non JSON content types", func(t *testing.T) {
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				_, _ = w.Write([]byte("\n<html><title>Login</title>" + strings.Repeat(" ", 1000) + "</html>"))
+			}),
+		)
+		defer srv.Close()
+
+		resp, err := NewRestClient("resource", false).GET(srv.URL)
+		assert.Nil(t, err)
+		_, err = Decode[user](resp)
+		var decodeErr *DecodeError
+		assert.ErrorAs(t, err, &decodeErr)
+		assert.Len(t, decodeErr.Body, maxBodySnippet)
+		assert.EqualError(t, err, `error decoding response body: unexpected content type "text/html; charset=utf-8", `+
+			`expected JSON, body starts with "<html><title>Login</title>"`)
+	})
+	t.Run("should accept JSON content types with parameters", func(t *testing.T) {
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				_, _ = w.Write([]byte(`{"name":"john"}`))
+			}),
+		)
+		defer srv.Close()
+
+		resp, err := NewRestClient("resource", false).GET(srv.URL)
+		assert.Nil(t, err)
+		result, err := Decode[user](resp)
+		assert.Nil(t, err)
+		assert.Equal(t, "john", result.Name)
+	})
+}
+
+func TestTypedRequests(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/missing" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.URL.Path == "/no-content" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			if r.URL.Path == "/empty" {
+				w.Header().Set("Content-Length", "0")
+				return
+			}
+			var body user
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(user{Name: r.Method + " " + body.Name})
+		}),
+	)
+	defer srv.Close()
+	client := NewRestClient("resource", false)
+
+	t.Run("should decode typed responses", func(t *testing.T) {
+		got, err := Get[user](client, srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, user{Name: "GET "}, got)
+
+		got, err = Post[user](client, srv.URL, user{Name: "john"})
+		assert.Nil(t, err)
+		assert.Equal(t, user{Name: "POST john"}, got)
+
+		got, err = Put[user](client, srv.URL, user{Name: "john"})
+		assert.Nil(t, err)
+		assert.Equal(t, user{Name: "PUT john"}, got)
+
+		got, err = Patch[user](client, srv.URL, user{Name: "john"})
+		assert.Nil(t, err)
+		assert.Equal(t, user{Name: "PATCH john"}, got)
+	})
+	t.Run("should return an HTTPError for non 2xx responses", func(t *testing.T) {
+		_, err := Get[user](client, srv.URL+"/missing")
+		var httpErr *HTTPError
+		assert.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+	})
+	t.Run("should return the zero value for 204 No Content and empty responses", func(t *testing.T) {
+		got, err := Put[user](client, srv.URL+"/no-content", user{Name: "john"})
+		assert.Nil(t, err)
+		assert.Equal(t, user{}, got)
+
+		_, err = Put[struct{}](client, srv.URL+"/no-content", user{Name: "john"})
+		assert.Nil(t, err)
+
+		got, err = Patch[user](client, srv.URL+"/empty", user{Name: "john"})
+		assert.Nil(t, err)
+		assert.Equal(t, user{}, got)
+	})
+}
+
+func TestWithMaxResponseBytes(t *testing.T) {
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"john"}`))
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should fail decoding bodies over the limit", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithMaxResponseBytes(10)
+		_, err := Get[map[string]string](client, srv.URL)
+		assert.ErrorIs(t, err, ErrResponseTooLarge)
+	})
+	t.Run("should read bodies within the limit", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithMaxResponseBytes(15)
+		got, err := Get[map[string]string](client, srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"name": "john"}, got)
+	})
+}
+
+func TestArrayBodies(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	var received []user
+	var accept string
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accept = r.Header.Get("Accept")
+			received = nil
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(append(received, user{Name: "created"}))
+		}),
+	)
+	defer srv.Close()
+	client := NewRestClient("resource", false)
+
+	t.Run("should send and decode top-level arrays", func(t *testing.T) {
+		users := []user{{Name: "john"}, {Name: "jane"}}
+		got, err := Post[[]user](client, srv.URL, users)
+		assert.Nil(t, err)
+		assert.Equal(t, users, received)
+		assert.Equal(t, []user{{Name: "john"}, {Name: "jane"}, {Name: "created"}}, got)
+	})
+	t.Run("should send an empty array rather than null", func(t *testing.T) {
+		got, err := Put[[]user](client, srv.URL, []user{})
+		assert.Nil(t, err)
+		assert.Equal(t, []user{}, received)
+		assert.Equal(t, []user{{Name: "created"}}, got)
+	})
+	t.Run("should send array bodies with a custom Accept header", func(t *testing.T) {
+		_, err := Patch[[]user](client, srv.URL, []user{{Name: "john"}}, func(req *http.Request) {
+			req.Header.Set("Accept", "application/vnd.users+json")
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "application/vnd.users+json", accept)
+		assert.Equal(t, []user{{Name: "john"}}, received)
+	})
+	t.Run("should return a DecodeError when an array is decoded into an object", func(t *testing.T) {
+		_, err := Post[user](client, srv.URL, []user{})
+		var decodeErr *DecodeError
+		assert.ErrorAs(t, err, &decodeErr)
+	})
+}
+
+func TestWithJSONCodec(t *testing.T) {
+	var received string
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			received = string(body)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"john"}`))
+		}),
+	)
+	defer srv.Close()
+
+	var marshalled, unmarshalled int
+	client := NewRestClient("resource", false).WithJSONCodec(
+		func(v any) ([]byte, error) {
+			marshalled++
+			return json.Marshal(v)
+		},
+		func(data []byte, v any) error {
+			unmarshalled++
+			return json.Unmarshal(data, v)
+		},
+	)
+
+	t.Run("should encode and decode with the codec", func(t *testing.T) {
+		got, err := Post[map[string]string](client, srv.URL, map[string]string{"name": "jane"}, func(req *http.Request) {
+			*req = *req.WithContext(context.Background())
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"name": "john"}, got)
+		assert.Equal(t, `{"name":"jane"}`, received)
+		assert.Equal(t, 1, marshalled)
+		assert.Equal(t, 1, unmarshalled)
+	})
+	t.Run("should decode responses of other clients with encoding/json", func(t *testing.T) {
+		_, err := Get[map[string]string](NewRestClient("resource", false), srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, unmarshalled)
+	})
+	t.Run("should return marshal errors", func(t *testing.T) {
+		failing := NewRestClient("resource", false).WithJSONCodec(
+			func(v any) ([]byte, error) { return nil, errors.New("marshal failed") },
+			json.Unmarshal,
+		)
+		_, err := failing.PUT(srv.URL, map[string]string{})
+		assert.EqualError(t, err, "marshal failed")
+	})
+}
+
+func TestWithStrictDecoding(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"john","age":42}`))
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should ignore unknown fields by default", func(t *testing.T) {
+		got, err := Get[user](NewRestClient("resource", false), srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, user{Name: "john"}, got)
+	})
+	t.Run("should fail on unknown fields with strict decoding", func(t *testing.T) {
+		_, err := Get[user](NewRestClient("resource", false).WithStrictDecoding(), srv.URL)
+		var decodeErr *DecodeError
+		assert.ErrorAs(t, err, &decodeErr)
+		assert.ErrorContains(t, err, `unknown field "age"`)
+	})
+	t.Run("should fail on trailing data with strict decoding", func(t *testing.T) {
+		var got user
+		assert.Error(t, unmarshalStrict([]byte(`{"name":"john"} {}`), &got))
+		assert.Nil(t, unmarshalStrict([]byte(` {"name":"john"} `), &got))
+	})
+}
+
+func TestPostInto(t *testing.T) {
+	type user struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/invalid" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			var created user
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			created.ID = "1"
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(created)
+		}),
+	)
+	defer srv.Close()
+	client := NewRestClient("resource", false)
+
+	t.Run("should decode the response into out", func(t *testing.T) {
+		var created user
+		err := PostInto(client, srv.URL, user{Name: "john"}, &created)
+		assert.Nil(t, err)
+		assert.Equal(t, user{ID: "1", Name: "john"}, created)
+	})
+	t.Run("should return an HTTPError and leave out unchanged", func(t *testing.T) {
+		created := user{Name: "unchanged"}
+		err := PostInto(client, srv.URL+"/invalid", user{Name: "john"}, &created)
+		var httpErr *HTTPError
+		assert.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+		assert.Equal(t, user{Name: "unchanged"}, created)
+	})
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DownloadTo performs a GET request and streams the response body to the file at path, creating its parent
+// directories, and returns the number of bytes written. The body is written to a temporary file that replaces the
+// file at path once the transfer is complete, so a failed transfer doesn't leave a partial file behind.
+// An *HTTPError is returned if the status code is not 2xx. Any content type is accepted, unless the request modifiers
+// set the Accept header.
+// Example:
+//
+//	written, err := client.DownloadTo(client.ResolveURL("/api/v1/reports/%s", reportID), "reports/report.pdf")
+func (c *RestClient) DownloadTo(url string, path string, requestModifier ...func(req *http.Request)) (int64, error) {
+	return c.download(url, path, nil, requestModifier)
+}
+
+// Checksum is the expected checksum of a download.
+type Checksum struct {
+	// Hash computes the checksum, e.g. sha256.New().
+	Hash hash.Hash
+	// Expected is the hex encoded expected checksum.
+	Expected string
+}
+
+// SHA256Checksum returns a Checksum verifying the hex encoded SHA-256 checksum.
+func SHA256Checksum(expected string) Checksum {
+	return Checksum{Hash: sha256.New(), Expected: expected}
+}
+
+// DownloadToWithChecksum works like DownloadTo but computes the checksum of the body while it is streamed to disk
+// and returns an error, without creating the file, if it doesn't match the expected checksum.
+// Example:
+//
+//	written, err := client.DownloadToWithChecksum(client.ResolveURL("/api/v1/artifacts/%s", artifactID),
+//		"artifacts/app.tar.gz", SHA256Checksum(expectedSHA256))
+func (c *RestClient) DownloadToWithChecksum(url string, path string, checksum Checksum, requestModifier ...func(req *http.Request)) (int64, error) {
+	return c.download(url, path, &checksum, requestModifier)
+}
+
+// download streams the response body to the file at path, verifying the checksum if not nil.
+func (c *RestClient) download(url string, path string, checksum *Checksum, requestModifier []func(req *http.Request)) (int64, error) {
+	modifiers := append([]func(req *http.Request){func(req *http.Request) {
+		req.Header.Set("Accept", "*/*")
+	}}, requestModifier...)
+	resp, err := c.GET(url, modifiers...)
+	if err != nil {
+		return 0, err
+	}
+	if !IsSuccess(resp) {
+		return 0, newStatusError(resp)
+	}
+	defer resp.Body.Close()
+
+	if checksum == nil {
+		return writeFile(path, resp.Body, nil)
+	}
+	checksum.Hash.Reset()
+	return writeFile(path, io.TeeReader(resp.Body, checksum.Hash), func() error {
+		actual := hex.EncodeToString(checksum.Hash.Sum(nil))
+		if !strings.EqualFold(actual, checksum.Expected) {
+			return fmt.Errorf("checksum mismatch: expected %s, got %s", checksum.Expected, actual)
+		}
+		return nil
+	})
+}
+
+// writeFile atomically writes the content of reader to the file at path. If verify is not nil it is called once
+// all content is written and the file is only created if it returns nil.
+func writeFile(path string, reader io.Reader, verify func() error) (int64, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, fmt.Errorf("error creating directory %s: %w", dir, err)
+	}
+
+	file, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return 0, fmt.Errorf("error creating file: %w", err)
+	}
+	written, err := io.Copy(file, reader)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && verify != nil {
+		err = verify()
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return written, fmt.Errorf("error writing %s: %w", path, err)
+	}
+	return written, nil
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadTo(t *testing.T) {
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/report":
+				_, _ = w.Write([]byte("report content"))
+			case "/accept":
+				_, _ = w.Write([]byte(r.Header.Get("Accept")))
+			case "/broken":
+				// announce more content than is sent so the transfer fails midway
+				w.Header().Set("Content-Length", "100")
+				_, _ = w.Write([]byte("partial"))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+	)
+	defer srv.Close()
+	client := NewRestClient("resource", false)
+
+	t.Run("should write the body to the file creating parent directories", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "reports", "report.txt")
+		written, err := client.DownloadTo(srv.URL+"/report", path)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(14), written)
+		content, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, "report content", string(content))
+	})
+	t.Run("should accept any content type unless set by a modifier", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "accept.txt")
+		_, err := client.DownloadTo(srv.URL+"/accept", path)
+		assert.Nil(t, err)
+		content, _ := os.ReadFile(path)
+		assert.Equal(t, "*/*", string(content))
+
+		_, err = client.DownloadTo(srv.URL+"/accept", path, func(req *http.Request) {
+			req.Header.Set("Accept", "application/pdf")
+		})
+		assert.Nil(t, err)
+		content, _ = os.ReadFile(path)
+		assert.Equal(t, "application/pdf", string(content))
+	})
+	t.Run("should remove the partial file when the transfer fails", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := client.DownloadTo(srv.URL+"/broken", filepath.Join(dir, "report.txt"))
+		assert.Error(t, err)
+		entries, err := os.ReadDir(dir)
+		assert.Nil(t, err)
+		assert.Empty(t, entries)
+	})
+	t.Run("should return an HTTPError for non 2xx responses", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := client.DownloadTo(srv.URL+"/missing", filepath.Join(dir, "report.txt"))
+		var httpErr *HTTPError
+		assert.ErrorAs(t, err, &httpErr)
+		entries, _ := os.ReadDir(dir)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestDownloadToWithChecksum(t *testing.T) {
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("artifact"))
+		}),
+	)
+	defer srv.Close()
+	client := NewRestClient("resource", false)
+	sum := sha256.Sum256([]byte("artifact"))
+	expected := hex.EncodeToString(sum[:])
+
+	t.Run("should write the file when the checksum matches", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "artifact.bin")
+		written, err := client.DownloadToWithChecksum(srv.URL, path, SHA256Checksum(strings.ToUpper(expected)))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(8), written)
+		content, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, "artifact", string(content))
+	})
+	t.Run("should delete the file when the checksum doesn't match", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := client.DownloadToWithChecksum(srv.URL, filepath.Join(dir, "artifact.bin"), SHA256Checksum("deadbeef"))
+		assert.ErrorContains(t, err, "checksum mismatch")
+		entries, err := os.ReadDir(dir)
+		assert.Nil(t, err)
+		assert.Empty(t, entries)
+	})
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+)
+
+// ErrClientNotReady is returned when a request is made with a URL resolved before the client was initialized.
+var ErrClientNotReady = errors.New("client not initialized")
+
+// ErrClientClosed is returned by requests made after the client was closed.
+var ErrClientClosed = errors.New("client closed")
+
+// ErrResponseTooLarge is returned when reading a response body larger than the limit set with WithMaxResponseBytes.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// ErrConnection is wrapped by errors of requests that could not be sent or got no response, e.g. because the
+// connection was refused or reset. These errors are usually worth retrying.
+var ErrConnection = errors.New("connection error")
+
+// ErrTimeout is wrapped by errors of requests that did not complete in time, either because a timeout set on the
+// client or request fired or because the request context deadline was exceeded.
+var ErrTimeout = errors.New("timeout")
+
+// classifyError wraps an error returned by http.Client.Do with ErrTimeout or ErrConnection, keeping the original
+// error in the chain. Cancellation of the request context is returned as is.
+func classifyError(err error) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return err
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return fmt.Errorf("%w: %w", ErrConnection, err)
+}
+
+// DecodeError is returned when a response body can not be decoded, because it has an unexpected content type or
+// holds invalid content.
+type DecodeError struct {
+	ContentType string
+	Body        []byte
+	Err         error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("error decoding response body: %s", e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPError is returned when a response has an unexpected status code.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *HTTPError) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf("unexpected status: %s", e.Status)
+	}
+	return fmt.Sprintf("unexpected status: %s: %s", e.Status, e.Body)
+}
+
+// newHTTPError reads and closes the response body and returns an HTTPError describing the response.
+func newHTTPError(resp *http.Response) *HTTPError {
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return &HTTPError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       body,
+	}
+}
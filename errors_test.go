@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithErrorOnStatus(t *testing.T) {
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/missing" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("not found"))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should return an HTTPError for status codes >= 400", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithErrorOnStatus()
+		resp, err := client.GET(srv.URL + "/missing")
+		assert.Nil(t, resp)
+		var httpErr *HTTPError
+		assert.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+		assert.Equal(t, "404 Not Found", httpErr.Status)
+		assert.Equal(t, []byte("not found"), httpErr.Body)
+	})
+	t.Run("should not return an error for successful responses", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithErrorOnStatus()
+		resp, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+	t.Run("should not return an error when not enabled", func(t *testing.T) {
+		client := NewRestClient("resource", false)
+		resp, err := client.GET(srv.URL + "/missing")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestTypedErrors(t *testing.T) {
+	t.Run("should return ErrConnection when the server can not be reached", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		srv.Close()
+
+		_, err := NewRestClient("resource", false).GET(srv.URL)
+		assert.ErrorIs(t, err, ErrConnection)
+		assert.NotErrorIs(t, err, ErrTimeout)
+	})
+	t.Run("should return ErrTimeout when the request times out", func(t *testing.T) {
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+			}),
+		)
+		defer srv.Close()
+
+		_, err := NewRestClient("resource", false).WithTimeout(20 * time.Millisecond).GET(srv.URL)
+		assert.ErrorIs(t, err, ErrTimeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotErrorIs(t, err, ErrConnection)
+	})
+	t.Run("should return cancellation as is", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer srv.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := NewRestClient("resource", false).GET(srv.URL, func(req *http.Request) {
+			*req = *req.WithContext(ctx)
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotErrorIs(t, err, ErrConnection)
+	})
+	t.Run("should return a DecodeError for invalid content", func(t *testing.T) {
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("{invalid"))
+			}),
+		)
+		defer srv.Close()
+
+		_, err := Get[map[string]string](NewRestClient("resource", false), srv.URL)
+		var decodeErr *DecodeError
+		assert.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, "{invalid", string(decodeErr.Body))
+		assert.Equal(t, "application/json", decodeErr.ContentType)
+	})
+	t.Run("should return a DecodeError for an unexpected content type", func(t *testing.T) {
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				_, _ = w.Write([]byte("<html></html>"))
+			}),
+		)
+		defer srv.Close()
+
+		_, err := Get[map[string]string](NewRestClient("resource", false), srv.URL)
+		var decodeErr *DecodeError
+		assert.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, "text/html", decodeErr.ContentType)
+	})
+}
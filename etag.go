@@ -0,0 +1,31 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ErrNotModified is returned by Decode and the decode helpers when the server responds with 304 Not Modified to a
+// conditional request, meaning a previously received response is still valid.
+var ErrNotModified = errors.New("not modified")
+
+// ETag returns the entity tag of the response, or an empty string if there is none.
+func ETag(resp *http.Response) string {
+	return resp.Header.Get("ETag")
+}
+
+// IfNoneMatchModifier returns a request modifier that makes the request conditional on the entity tag, so the server
+// responds with 304 Not Modified if the resource did not change. An empty etag leaves the request unchanged.
+// Example:
+//
+//	user, err := Get[User](client, url, IfNoneMatchModifier(etag))
+//	if errors.Is(err, ErrNotModified) {
+//		// use the cached user
+//	}
+func IfNoneMatchModifier(etag string) func(req *http.Request) {
+	return func(req *http.Request) {
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionalRequests(t *testing.T) {
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"john"}`))
+		}),
+	)
+	defer srv.Close()
+	client := NewRestClient("resource", false)
+
+	t.Run("should capture the ETag of the response", func(t *testing.T) {
+		resp, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Nil(t, resp.Body.Close())
+		assert.Equal(t, `"v1"`, ETag(resp))
+	})
+	t.Run("should return ErrNotModified when the resource did not change", func(t *testing.T) {
+		_, err := Get[map[string]string](client, srv.URL, IfNoneMatchModifier(`"v1"`))
+		assert.ErrorIs(t, err, ErrNotModified)
+	})
+	t.Run("should decode the response when the resource changed", func(t *testing.T) {
+		user, err := Get[map[string]string](client, srv.URL, IfNoneMatchModifier(`"v0"`))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"name": "john"}, user)
+	})
+	t.Run("should not set the header for an empty ETag", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		IfNoneMatchModifier("")(req)
+		assert.Empty(t, req.Header.Values("If-None-Match"))
+	})
+}
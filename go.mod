@@ -5,11 +5,20 @@ go 1.21.6
 require (
 	github.com/kapetacom/sdk-go-config v0.1.3
 	github.com/stretchr/testify v1.8.4
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
+	golang.org/x/net v0.21.0
+	golang.org/x/sync v0.6.0
+	golang.org/x/text v0.14.0
+	golang.org/x/time v0.5.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/kapetacom/schemas/packages/go v0.0.0-20240209083259-f5ce079d8abc // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel/metric v1.24.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"golang.org/x/net/http2"
+)
+
+// WithH2C makes the client speak HTTP/2 over plaintext connections without upgrade negotiation (h2c with prior
+// knowledge), for backends that only accept cleartext HTTP/2. It replaces the transport, so options configuring the
+// http.Transport like WithTLSConfig or WithProxy have no effect.
+func (c *RestClient) WithH2C() *RestClient {
+	dialer := &net.Dialer{}
+	return c.WithTransport(&http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, addr)
+		},
+	})
+}
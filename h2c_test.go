@@ -0,0 +1,34 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func TestWithH2C(t *testing.T) {
+	var protoMajor int
+	srv := httptest.NewServer(h2c.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			protoMajor = r.ProtoMajor
+		}),
+		&http2.Server{},
+	))
+	defer srv.Close()
+
+	t.Run("should use HTTP/1.1 by default", func(t *testing.T) {
+		_, err := NewRestClient("resource", false).GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, protoMajor)
+	})
+	t.Run("should use HTTP/2 over plaintext", func(t *testing.T) {
+		resp, err := NewRestClient("resource", false).WithH2C().GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, protoMajor)
+		assert.Equal(t, 2, resp.ProtoMajor)
+	})
+}
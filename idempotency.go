@@ -0,0 +1,49 @@
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// WithIdempotencyKeys sets a new random Idempotency-Key header on every POST and PATCH request that does not have
+// one. The key stays the same for all attempts of a request, so together with WithRetry a backend supporting
+// idempotency keys can detect retries. Requests with an Idempotency-Key header are retried like idempotent requests.
+// Use IdempotencyKeyModifier to set the key of a request, e.g. to reuse it when a call is repeated.
+func (c *RestClient) WithIdempotencyKeys() *RestClient {
+	c.idempotencyKeys = true
+	return c
+}
+
+// IdempotencyKeyModifier returns a request modifier that sets the Idempotency-Key header to key.
+// Example:
+//
+//	key := NewIdempotencyKey()
+//	response, err := client.POST(client.ResolveURL("/api/v1/payments"), payment, IdempotencyKeyModifier(key))
+func IdempotencyKeyModifier(key string) func(req *http.Request) {
+	return func(req *http.Request) {
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+}
+
+// NewIdempotencyKey returns a random version 4 UUID to use as idempotency key.
+func NewIdempotencyKey() string {
+	var uuid [16]byte
+	// crypto/rand.Read never returns an error on supported platforms
+	_, _ = rand.Read(uuid[:])
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+}
+
+// setIdempotencyKey sets an idempotency key on POST and PATCH requests without one, if enabled.
+func (c *RestClient) setIdempotencyKey(req *http.Request) {
+	if !c.idempotencyKeys || req.Header.Get(idempotencyKeyHeader) != "" {
+		return
+	}
+	if req.Method == http.MethodPost || req.Method == http.MethodPatch {
+		req.Header.Set(idempotencyKeyHeader, NewIdempotencyKey())
+	}
+}
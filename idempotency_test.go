@@ -0,0 +1,57 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithIdempotencyKeys(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			if len(keys) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should reuse the same key for all attempts of a POST", func(t *testing.T) {
+		keys = nil
+		client := NewRestClient("resource", false).WithRetry(3, time.Millisecond).WithIdempotencyKeys()
+		resp, err := client.POST(srv.URL, map[string]string{"amount": "10"})
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Len(t, keys, 3)
+		assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), keys[0])
+		assert.Equal(t, keys[0], keys[1])
+		assert.Equal(t, keys[0], keys[2])
+	})
+	t.Run("should use a new key for every call", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithIdempotencyKeys()
+		keys = []string{"", ""}
+		_, _ = client.POST(srv.URL, nil)
+		_, _ = client.PATCH(srv.URL, nil)
+		assert.NotEqual(t, keys[2], keys[3])
+		assert.NotEmpty(t, keys[3])
+	})
+	t.Run("should use the key of the modifier", func(t *testing.T) {
+		keys = nil
+		client := NewRestClient("resource", false).WithRetry(3, time.Millisecond).WithIdempotencyKeys()
+		_, err := client.POST(srv.URL, nil, IdempotencyKeyModifier("my-key"))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"my-key", "my-key", "my-key"}, keys)
+	})
+	t.Run("should not set keys on other methods or when disabled", func(t *testing.T) {
+		keys = []string{"", ""}
+		_, _ = NewRestClient("resource", false).WithIdempotencyKeys().PUT(srv.URL, nil)
+		_, _ = NewRestClient("resource", false).POST(srv.URL, nil)
+		assert.Equal(t, []string{"", "", "", ""}, keys)
+	})
+}
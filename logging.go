@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// DefaultRedactedHeaders are the headers whose values are redacted in logs unless WithRedactedHeaders is used.
+var DefaultRedactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// WithLogger enables debug logging of every request attempt, logging the method, URL and headers of the request and
+// the status and duration of the response. The values of sensitive headers are redacted, see WithRedactedHeaders.
+// Messages about the initialization of the client are logged with the logger as well, instead of the standard logger.
+// Call WithLogger before WithConfigProvider, or use autoInit false, to also route the first initialization message.
+func (c *RestClient) WithLogger(logger *slog.Logger) *RestClient {
+	c.logger = logger
+	return c
+}
+
+// WithRedactedHeaders sets the headers whose values are replaced with "***" in logs and debug output, replacing
+// DefaultRedactedHeaders. To add a header to the defaults use:
+//
+//	client.WithRedactedHeaders(append(DefaultRedactedHeaders, "X-Session-Token")...)
+func (c *RestClient) WithRedactedHeaders(headers ...string) *RestClient {
+	c.redactedHeaders = headers
+	return c
+}
+
+// logf logs a message about the state of the client with the logger set with WithLogger, or with the standard logger
+// if none is set.
+func (c *RestClient) logf(level slog.Level, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if c.logger == nil {
+		log.Println(msg)
+		return
+	}
+	c.logger.LogAttrs(context.Background(), level, msg,
+		slog.String("client", c.Name()),
+		slog.String("resource", c.resourceName),
+	)
+}
+
+// logRequest logs the request before it is sent.
+func (c *RestClient) logRequest(req *http.Request) {
+	if c.logger == nil || !c.logger.Enabled(req.Context(), slog.LevelDebug) {
+		return
+	}
+	headers := c.redactHeaders(req.Header)
+	attrs := []slog.Attr{
+		slog.String("client", c.Name()),
+		slog.String("resource", c.resourceName),
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Any("headers", headers),
+	}
+	if c.logCurl {
+		if command, err := dumpAsCurl(req, headers); err == nil {
+			attrs = append(attrs, slog.String("curl", command))
+		}
+	}
+	c.logger.LogAttrs(req.Context(), slog.LevelDebug, "sending request", attrs...)
+}
+
+// logResponse logs the result of the request.
+func (c *RestClient) logResponse(ctx context.Context, req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	if c.logger == nil || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	if err != nil {
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "request failed",
+			slog.String("client", c.Name()),
+			slog.String("resource", c.resourceName),
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.String()),
+			slog.Duration("duration", duration),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "received response",
+		slog.String("client", c.Name()),
+		slog.String("resource", c.resourceName),
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Int("status", resp.StatusCode),
+		slog.Duration("duration", duration),
+		slog.Any("headers", c.redactHeaders(resp.Header)),
+	)
+}
+
+// redactHeaders returns a copy of the headers with the values of sensitive headers replaced.
+func (c *RestClient) redactHeaders(headers http.Header) http.Header {
+	redactedHeaders := c.redactedHeaders
+	if redactedHeaders == nil {
+		redactedHeaders = DefaultRedactedHeaders
+	}
+	return redactHeaderValues(headers, redactedHeaders)
+}
+
+// redactHeaderValues returns a copy of the headers with the values of the named headers replaced.
+func redactHeaderValues(headers http.Header, names []string) http.Header {
+	redacted := headers.Clone()
+	for _, name := range names {
+		values := redacted.Values(name)
+		for i := range values {
+			values[i] = "***"
+		}
+	}
+	return redacted
+}
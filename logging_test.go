@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	config "github.com/kapetacom/sdk-go-config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLogger(t *testing.T) {
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should log requests and responses at debug level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		client := NewRestClient("resource", false).WithLogger(logger)
+		_, err := client.POST(srv.URL, nil, BearerAuthModifier("secret-token"))
+		assert.Nil(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, `msg="sending request"`)
+		assert.Contains(t, output, "method=POST")
+		assert.Contains(t, output, `msg="received response"`)
+		assert.Contains(t, output, "status=201")
+		assert.Contains(t, output, "duration=")
+		assert.NotContains(t, output, "secret-token")
+	})
+	t.Run("should log the client name", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		_, err := NewRestClient("resource", false).WithLogger(logger).GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Contains(t, buf.String(), "client=resource resource=resource")
+
+		buf.Reset()
+		_, err = NewRestClient("resource", false).WithName("users-api").WithLogger(logger).GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Contains(t, buf.String(), "client=users-api resource=resource")
+	})
+	t.Run("should not log when debug is disabled", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+		client := NewRestClient("resource", false).WithLogger(logger)
+		_, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Empty(t, buf.String())
+	})
+	t.Run("should log failed requests", func(t *testing.T) {
+		closed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		closed.Close()
+
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		client := NewRestClient("resource", false).WithLogger(logger)
+		_, err := client.GET(closed.URL)
+		assert.Error(t, err)
+		assert.Contains(t, buf.String(), `msg="request failed"`)
+	})
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := http.Header{
+		"Authorization": []string{"Bearer token"},
+		"Cookie":        []string{"session=abc"},
+		"X-Api-Key":     []string{"key"},
+		"X-Secret":      []string{"secret"},
+		"Accept":        []string{"application/json"},
+	}
+	t.Run("should redact the default headers", func(t *testing.T) {
+		redacted := NewRestClient("resource", false).redactHeaders(headers)
+		assert.Equal(t, "***", redacted.Get("Authorization"))
+		assert.Equal(t, "***", redacted.Get("Cookie"))
+		assert.Equal(t, "***", redacted.Get("X-Api-Key"))
+		assert.Equal(t, "secret", redacted.Get("X-Secret"))
+		assert.Equal(t, "application/json", redacted.Get("Accept"))
+		assert.Equal(t, "Bearer token", headers.Get("Authorization"))
+	})
+	t.Run("should redact the configured headers", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithRedactedHeaders(append(DefaultRedactedHeaders, "X-Secret")...)
+		redacted := client.redactHeaders(headers)
+		assert.Equal(t, "***", redacted.Get("Authorization"))
+		assert.Equal(t, "***", redacted.Get("X-Secret"))
+	})
+}
+
+func TestLifecycleLogging(t *testing.T) {
+	t.Run("should log initialization with the logger", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+		mock := &config.ConfigProviderMock{
+			GetServiceAddressFunc: func(serviceName string, portType string) (string, error) {
+				return "http://localhost:8080", nil
+			},
+		}
+		NewRestClient("resource", false).WithLogger(logger).WithConfigProvider(mock)
+		assert.Contains(t, buf.String(), `level=INFO msg="REST client ready for resource --> http://localhost:8080" client=resource`)
+	})
+	t.Run("should log initialization errors as warnings", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+		mock := &config.ConfigProviderMock{
+			GetServiceAddressFunc: func(serviceName string, portType string) (string, error) {
+				return "", nil
+			},
+		}
+		NewRestClient("resource", false).WithLogger(logger).WithConfigProvider(mock)
+		assert.Contains(t, buf.String(), "level=WARN")
+		assert.Contains(t, buf.String(), "not initialized")
+	})
+	t.Run("should be silenced by the logger level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelError}))
+		mock := &config.ConfigProviderMock{
+			GetServiceAddressFunc: func(serviceName string, portType string) (string, error) {
+				return "http://localhost:8080", nil
+			},
+		}
+		NewRestClient("resource", false).WithLogger(logger).WithConfigProvider(mock)
+		assert.Empty(t, buf.String())
+	})
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// MetricsRecorder records metrics about the requests made by a RestClient, e.g. as Prometheus counters and histograms.
+type MetricsRecorder interface {
+	// ObserveRequest is called after each request with the name of the client, see WithName, the request method, the
+	// response status code and the duration of the request. statusCode is 0 if the request failed without a response.
+	ObserveRequest(name string, method string, statusCode int, duration time.Duration)
+}
+
+// WithMetrics sets the MetricsRecorder that is called after each request.
+func (c *RestClient) WithMetrics(recorder MetricsRecorder) *RestClient {
+	c.metrics = recorder
+	return c
+}
+
+// observeRequest reports the result of a request to the MetricsRecorder, if any.
+func (c *RestClient) observeRequest(req *http.Request, resp *http.Response, duration time.Duration) {
+	if c.metrics == nil {
+		return
+	}
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	c.metrics.ObserveRequest(c.Name(), req.Method, statusCode, duration)
+}
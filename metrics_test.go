@@ -0,0 +1,70 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type observation struct {
+	resource   string
+	method     string
+	statusCode int
+	duration   time.Duration
+}
+
+type recordingMetrics struct {
+	observations []observation
+}
+
+func (m *recordingMetrics) ObserveRequest(resource string, method string, statusCode int, duration time.Duration) {
+	m.observations = append(m.observations, observation{resource, method, statusCode, duration})
+}
+
+func TestWithMetrics(t *testing.T) {
+	t.Run("should observe each request", func(t *testing.T) {
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			}),
+		)
+		defer srv.Close()
+
+		metrics := &recordingMetrics{}
+		client := NewRestClient("resource", false).WithMetrics(metrics)
+		_, err := client.DELETE(srv.URL)
+		assert.Nil(t, err)
+
+		assert.Len(t, metrics.observations, 1)
+		assert.Equal(t, "resource", metrics.observations[0].resource)
+		assert.Equal(t, http.MethodDelete, metrics.observations[0].method)
+		assert.Equal(t, http.StatusAccepted, metrics.observations[0].statusCode)
+		assert.Greater(t, metrics.observations[0].duration, time.Duration(0))
+	})
+	t.Run("should observe failed requests with status code 0", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		srv.Close()
+
+		metrics := &recordingMetrics{}
+		client := NewRestClient("resource", false).WithMetrics(metrics)
+		_, err := client.GET(srv.URL)
+		assert.Error(t, err)
+
+		assert.Len(t, metrics.observations, 1)
+		assert.Equal(t, 0, metrics.observations[0].statusCode)
+	})
+	t.Run("should observe requests with the client name", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer srv.Close()
+
+		metrics := &recordingMetrics{}
+		client := NewRestClient("resource", false).WithName("users-api").WithMetrics(metrics)
+		_, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+
+		assert.Equal(t, "users-api", metrics.observations[0].resource)
+	})
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"net/http"
+)
+
+// RoundTripperFunc sends a request and returns the response, it implements http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the sending of a request. It can modify the request before calling next, modify the response
+// returned by next or short-circuit the request by returning without calling next.
+type Middleware func(next RoundTripperFunc) RoundTripperFunc
+
+// WithMiddleware adds middlewares that run around every request. Middlewares run in the order they are added, the
+// first one added sees the request first and the response last. Retries happen inside the middlewares, so a
+// middleware sees a single request even if it is retried.
+// Example:
+//
+//	client.WithMiddleware(func(next RoundTripperFunc) RoundTripperFunc {
+//		return func(req *http.Request) (*http.Response, error) {
+//			start := time.Now()
+//			resp, err := next(req)
+//			log.Printf("%s %s took %s", req.Method, req.URL, time.Since(start))
+//			return resp, err
+//		}
+//	})
+func (c *RestClient) WithMiddleware(middleware ...Middleware) *RestClient {
+	c.middlewares = append(c.middlewares, middleware...)
+	return c
+}
+
+// roundTripper returns the function that sends a request through the middlewares.
+func (c *RestClient) roundTripper() RoundTripperFunc {
+	next := RoundTripperFunc(c.sendWithRetry)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		next = c.middlewares[i](next)
+	}
+	return next
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	var header string
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Get("X-Order")
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should run middlewares in registration order", func(t *testing.T) {
+		var order []string
+		middleware := func(name string) Middleware {
+			return func(next RoundTripperFunc) RoundTripperFunc {
+				return func(req *http.Request) (*http.Response, error) {
+					order = append(order, "before "+name)
+					req.Header.Add("X-Order", name)
+					resp, err := next(req)
+					order = append(order, "after "+name)
+					return resp, err
+				}
+			}
+		}
+		client := NewRestClient("resource", false).WithMiddleware(middleware("first"), middleware("second"))
+		_, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"before first", "before second", "after second", "after first"}, order)
+		assert.Equal(t, "first", header)
+	})
+	t.Run("should allow middlewares to short-circuit and replace the response", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithMiddleware(func(next RoundTripperFunc) RoundTripperFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusTeapot,
+					Body:       io.NopCloser(strings.NewReader("short-circuited")),
+					Request:    req,
+				}, nil
+			}
+		})
+		resp, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusTeapot, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "short-circuited", string(body))
+	})
+}
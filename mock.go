@@ -0,0 +1,123 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// MockTransport is an http.RoundTripper for tests of code using a RestClient. It returns queued responses by method
+// and path and records every request so the test can assert on them, without starting a server.
+// Example:
+//
+//	mock := NewMockTransport().RespondJSON(http.MethodGet, "/api/v1/users/1", http.StatusOK, User{ID: "1"})
+//	client := NewRestClientWithBaseURL("http://users").WithTransport(mock)
+//	...
+//	requests := mock.Requests()
+type MockTransport struct {
+	mu        sync.Mutex
+	responses map[string][]MockResponse
+	requests  []RecordedRequest
+}
+
+// MockResponse is a response returned by a MockTransport.
+type MockResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// RecordedRequest is a request sent through a MockTransport.
+type RecordedRequest struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+// NewMockTransport creates a MockTransport without responses.
+func NewMockTransport() *MockTransport {
+	return &MockTransport{responses: make(map[string][]MockResponse)}
+}
+
+// Respond queues a response for requests with the method and URL path. Queued responses are returned in order and
+// the last one is returned again for any further requests.
+func (m *MockTransport) Respond(method string, path string, resp MockResponse) *MockTransport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := method + " " + path
+	m.responses[key] = append(m.responses[key], resp)
+	return m
+}
+
+// RespondJSON queues a response with the body encoded as JSON, see Respond.
+func (m *MockTransport) RespondJSON(method string, path string, statusCode int, body any) *MockTransport {
+	data, err := json.Marshal(body)
+	if err != nil {
+		panic(fmt.Errorf("error encoding mock response: %s", err))
+	}
+	return m.Respond(method, path, MockResponse{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       data,
+	})
+}
+
+// Requests returns the requests sent so far, in order.
+func (m *MockTransport) Requests() []RecordedRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]RecordedRequest(nil), m.requests...)
+}
+
+// RoundTrip records the request and returns the next queued response for its method and path, or an error if
+// there is none.
+func (m *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = data
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: req.Header.Clone(),
+		Body:   body,
+	})
+
+	key := req.Method + " " + req.URL.Path
+	queued := m.responses[key]
+	if len(queued) == 0 {
+		return nil, fmt.Errorf("no mock response for %s", key)
+	}
+	resp := queued[0]
+	if len(queued) > 1 {
+		m.responses[key] = queued[1:]
+	}
+
+	header := resp.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}, nil
+}
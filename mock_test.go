@@ -0,0 +1,51 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockTransport(t *testing.T) {
+	t.Run("should return queued responses and record requests", func(t *testing.T) {
+		mock := NewMockTransport().
+			RespondJSON(http.MethodGet, "/users/1", http.StatusOK, map[string]string{"name": "john"}).
+			Respond(http.MethodPost, "/users", MockResponse{StatusCode: http.StatusCreated})
+		client := NewRestClientWithBaseURL("http://users").WithTransport(mock)
+
+		user, err := Get[map[string]string](client, client.ResolveURL("/users/1"))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"name": "john"}, user)
+
+		resp, err := client.POST(client.ResolveURL("/users"), map[string]string{"name": "jane"}, BearerAuthModifier("token"))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "201 Created", resp.Status)
+
+		requests := mock.Requests()
+		assert.Len(t, requests, 2)
+		assert.Equal(t, "GET", requests[0].Method)
+		assert.Equal(t, "http://users/users/1", requests[0].URL)
+		assert.Equal(t, `{"name":"jane"}`, string(requests[1].Body))
+		assert.Equal(t, "Bearer token", requests[1].Header.Get("Authorization"))
+	})
+	t.Run("should return responses in order and repeat the last one", func(t *testing.T) {
+		mock := NewMockTransport().
+			Respond(http.MethodGet, "/status", MockResponse{StatusCode: http.StatusServiceUnavailable}).
+			Respond(http.MethodGet, "/status", MockResponse{StatusCode: http.StatusOK})
+		client := NewRestClientWithBaseURL("http://users").WithTransport(mock)
+
+		for _, expected := range []int{http.StatusServiceUnavailable, http.StatusOK, http.StatusOK} {
+			resp, err := client.GET(client.ResolveURL("/status"))
+			assert.Nil(t, err)
+			assert.Equal(t, expected, resp.StatusCode)
+		}
+	})
+	t.Run("should return an error for requests without a response", func(t *testing.T) {
+		client := NewRestClientWithBaseURL("http://users").WithTransport(NewMockTransport())
+		_, err := client.DELETE(client.ResolveURL("/users/1"))
+		assert.ErrorContains(t, err, "no mock response for DELETE /users/1")
+		assert.Len(t, client.HTTPClient.Transport.(*MockTransport).Requests(), 1)
+	})
+}
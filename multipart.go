@@ -0,0 +1,82 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+)
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// FileUpload describes a file sent as part of a multipart/form-data request.
+type FileUpload struct {
+	// FieldName is the form field name of the file.
+	FieldName string
+	// FileName is the name of the file reported to the server.
+	FileName string
+	// ContentType of the file, defaults to application/octet-stream.
+	ContentType string
+	// Content is the file content, it is streamed and not buffered in memory.
+	Content io.Reader
+}
+
+// POSTMultipart performs a POST request sending the fields and files as multipart/form-data.
+// The body is streamed to the server as it is written, so large files are not buffered in memory.
+// Example:
+//
+//	file, err := os.Open("avatar.png")
+//	...
+//	response, err := client.POSTMultipart(client.ResolveURL("/api/v1/users/%s/avatar", userID),
+//		map[string]string{"description": "profile picture"},
+//		[]FileUpload{{FieldName: "avatar", FileName: "avatar.png", ContentType: "image/png", Content: file}},
+//	)
+func (c *RestClient) POSTMultipart(url string, fields map[string]string, files []FileUpload, requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeMultipart(writer, fields, files))
+	}()
+
+	resp, err := c.doRaw(http.MethodPost, url, writer.FormDataContentType(), pr, -1, requestModifier)
+	// unblock the writer if the request failed before the body was fully consumed
+	_ = pr.Close()
+	return resp, err
+}
+
+// writeMultipart writes the fields, sorted by name, followed by the files to the multipart writer.
+func writeMultipart(writer *multipart.Writer, fields map[string]string, files []FileUpload) error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writer.WriteField(name, fields[name]); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range files {
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+			quoteEscaper.Replace(file.FieldName), quoteEscaper.Replace(file.FileName)))
+		header.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, file.Content); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
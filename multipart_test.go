@@ -0,0 +1,49 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPOSTMultipart(t *testing.T) {
+	t.Run("should send fields and files as multipart form data", func(t *testing.T) {
+		var description, fileName, fileContentType, fileContent string
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseMultipartForm(1 << 20); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				description = r.FormValue("description")
+				file, header, err := r.FormFile("avatar")
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				defer file.Close()
+				content, _ := io.ReadAll(file)
+				fileName = header.Filename
+				fileContentType = header.Header.Get("Content-Type")
+				fileContent = string(content)
+			}),
+		)
+		defer srv.Close()
+
+		client := NewRestClient("resource", false)
+		resp, err := client.POSTMultipart(srv.URL,
+			map[string]string{"description": "profile picture"},
+			[]FileUpload{{FieldName: "avatar", FileName: "avatar.png", ContentType: "image/png", Content: strings.NewReader("png data")}},
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "profile picture", description)
+		assert.Equal(t, "avatar.png", fileName)
+		assert.Equal(t, "image/png", fileContentType)
+		assert.Equal(t, "png data", fileContent)
+	})
+}
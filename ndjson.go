@@ -0,0 +1,159 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+// NDJSONStream decodes a newline-delimited JSON response body one line at a time as it arrives, without buffering
+// the whole body in memory. Empty lines are skipped.
+// Example:
+//
+//	stream, err := GetNDJSON[Event](client, client.ResolveURL("/api/v1/events"))
+//	if err != nil {
+//		return err
+//	}
+//	defer stream.Close()
+//	for stream.Next() {
+//		handle(stream.Value())
+//	}
+//	return stream.Err()
+type NDJSONStream[T any] struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+	codec  *jsonCodec
+	value  T
+	err    error
+}
+
+// DecodeNDJSON returns a stream decoding the newline-delimited JSON response body into values of type T. The caller
+// must close the stream. An *HTTPError is returned if the status code is not 2xx and a *DecodeError if the response
+// content type is neither NDJSON nor JSON, in both cases the body is closed.
+func DecodeNDJSON[T any](resp *http.Response) (*NDJSONStream[T], error) {
+	if !IsSuccess(resp) {
+		return nil, newStatusError(resp)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if !isNDJSONContentType(contentType) {
+		defer resp.Body.Close()
+		return nil, newContentTypeError(contentType, "NDJSON", resp.Body)
+	}
+	if err := checkUTF8Charset(contentType); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	return &NDJSONStream[T]{
+		body:   resp.Body,
+		reader: bufio.NewReader(resp.Body),
+		codec:  responseJSONCodec(resp),
+	}, nil
+}
+
+// GetNDJSON performs a GET request accepting newline-delimited JSON and returns a stream decoding the response into
+// values of type T, see DecodeNDJSON.
+func GetNDJSON[T any](c *RestClient, url string, requestModifier ...func(req *http.Request)) (*NDJSONStream[T], error) {
+	modifiers := append([]func(req *http.Request){func(req *http.Request) {
+		req.Header.Set("Accept", ndjsonContentType)
+	}}, requestModifier...)
+	resp, err := c.GET(url, modifiers...)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeNDJSON[T](resp)
+}
+
+// Next decodes the next value, which is then returned by Value. It returns false at the end of the body or when an
+// error occurred, which is returned by Err.
+func (s *NDJSONStream[T]) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	for {
+		line, err := s.reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var value T
+			if unmarshalErr := s.codec.unmarshal(line, &value); unmarshalErr != nil {
+				s.err = &DecodeError{ContentType: ndjsonContentType, Body: line, Err: unmarshalErr}
+				return false
+			}
+			s.value = value
+			return true
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				s.err = fmt.Errorf("error reading response body: %w", err)
+			}
+			return false
+		}
+	}
+}
+
+// Value returns the value decoded by the last call to Next.
+func (s *NDJSONStream[T]) Value() T {
+	return s.value
+}
+
+// Err returns the error that stopped the stream, nil if the end of the body was reached.
+func (s *NDJSONStream[T]) Err() error {
+	return s.err
+}
+
+// Close closes the response body.
+func (s *NDJSONStream[T]) Close() error {
+	return s.body.Close()
+}
+
+// POSTNDJSON performs a POST request streaming the values yielded by values as newline-delimited JSON, without
+// buffering the body in memory. values has the signature of an iter.Seq and stops when yield returns false, which
+// happens when the request fails.
+// Example:
+//
+//	response, err := POSTNDJSON(client, client.ResolveURL("/api/v1/events"), func(yield func(Event) bool) {
+//		for _, event := range events {
+//			if !yield(event) {
+//				return
+//			}
+//		}
+//	})
+func POSTNDJSON[T any](c *RestClient, url string, values func(yield func(T) bool), requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	reader, writer := io.Pipe()
+	codec := c.jsonCodec()
+	go func() {
+		var err error
+		values(func(value T) bool {
+			var data []byte
+			if data, err = codec.marshal(value); err != nil {
+				return false
+			}
+			if _, err = writer.Write(append(data, '\n')); err != nil {
+				return false
+			}
+			return true
+		})
+		_ = writer.CloseWithError(err)
+	}()
+	resp, err := c.POSTStream(url, ndjsonContentType, reader, -1, requestModifier...)
+	// unblock the producer if the request ended before consuming the whole body
+	_ = reader.Close()
+	return resp, err
+}
+
+// isNDJSONContentType returns true if the content type is a newline-delimited JSON or JSON media type.
+func isNDJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case ndjsonContentType, "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return true
+	}
+	return isJSONContentType(contentType)
+}
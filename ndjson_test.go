@@ -0,0 +1,121 @@
+package client
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNDJSON(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	t.Run("should decode each line as it arrives", func(t *testing.T) {
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/x-ndjson", r.Header.Get("Accept"))
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			_, _ = w.Write([]byte("{\"id\":1}\n\n"))
+			w.(http.Flusher).Flush()
+			<-release
+			_, _ = w.Write([]byte("{\"id\":2}\n{\"id\":3}"))
+		}))
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL)
+		stream, err := GetNDJSON[item](client, client.ResolveURL("/items"))
+		assert.Nil(t, err)
+		defer stream.Close()
+
+		assert.True(t, stream.Next())
+		assert.Equal(t, item{ID: 1}, stream.Value())
+		close(release)
+
+		var ids []int
+		for stream.Next() {
+			ids = append(ids, stream.Value().ID)
+		}
+		assert.Nil(t, stream.Err())
+		assert.Equal(t, []int{2, 3}, ids)
+	})
+	t.Run("should stop at an invalid line", func(t *testing.T) {
+		mock := NewMockTransport().Respond(http.MethodGet, "/items", MockResponse{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/x-ndjson"}},
+			Body:       []byte("{\"id\":1}\nnot json\n{\"id\":2}\n"),
+		})
+		client := NewRestClientWithBaseURL("http://items").WithTransport(mock)
+
+		stream, err := GetNDJSON[item](client, client.ResolveURL("/items"))
+		assert.Nil(t, err)
+		assert.True(t, stream.Next())
+		assert.False(t, stream.Next())
+		var decodeErr *DecodeError
+		assert.True(t, errors.As(stream.Err(), &decodeErr))
+		assert.Equal(t, "not json\n", string(decodeErr.Body))
+		assert.False(t, stream.Next())
+	})
+	t.Run("should return an error for other content types", func(t *testing.T) {
+		mock := NewMockTransport().Respond(http.MethodGet, "/items", MockResponse{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"text/html"}},
+		})
+		client := NewRestClientWithBaseURL("http://items").WithTransport(mock)
+
+		_, err := GetNDJSON[item](client, client.ResolveURL("/items"))
+		var decodeErr *DecodeError
+		assert.True(t, errors.As(err, &decodeErr))
+	})
+	t.Run("should return an HTTPError for error responses", func(t *testing.T) {
+		mock := NewMockTransport().Respond(http.MethodGet, "/items", MockResponse{StatusCode: http.StatusNotFound})
+		client := NewRestClientWithBaseURL("http://items").WithTransport(mock)
+
+		_, err := GetNDJSON[item](client, client.ResolveURL("/items"))
+		var httpErr *HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+	})
+	t.Run("should stream values as NDJSON", func(t *testing.T) {
+		var received []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+			scanner := bufio.NewScanner(r.Body)
+			for scanner.Scan() {
+				received = append(received, scanner.Text())
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL)
+		resp, err := POSTNDJSON(client, client.ResolveURL("/items"), func(yield func(item) bool) {
+			for i := 1; i <= 3; i++ {
+				if !yield(item{ID: i}) {
+					return
+				}
+			}
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		assert.Equal(t, []string{`{"id":1}`, `{"id":2}`, `{"id":3}`}, received)
+	})
+	t.Run("should fail the request when a value can not be encoded", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(io.Discard, r.Body)
+		}))
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL)
+		_, err := POSTNDJSON(client, client.ResolveURL("/items"), func(yield func(any) bool) {
+			if yield(item{ID: 1}) {
+				yield(func() {})
+			}
+		})
+		assert.NotNil(t, err)
+	})
+}
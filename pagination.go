@@ -0,0 +1,114 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Paginate fetches the pages of a paginated endpoint starting at url. Each page is decoded from JSON into T, see
+// Decode, and passed to handlePage together with its response, whose body is already closed. handlePage returns the
+// URL of the next page, which may be relative to the current page, or an empty string when there are no more pages.
+// Pagination stops at the first error, which is returned.
+// Example:
+//
+//	err := Paginate(client, client.ResolveURL("/api/v1/users"), func(page UserPage, resp *http.Response) (string, error) {
+//		users = append(users, page.Users...)
+//		return page.NextURL, nil
+//	})
+//
+// For endpoints paginating with a Link header the next URL can be taken from ParseLinkHeader(resp)["next"].
+func Paginate[T any](c *RestClient, url string, handlePage func(page T, resp *http.Response) (string, error), requestModifier ...func(req *http.Request)) error {
+	for url != "" {
+		resp, err := c.GET(url, requestModifier...)
+		if err != nil {
+			return err
+		}
+		page, err := Decode[T](resp)
+		if err != nil {
+			return err
+		}
+		next, err := handlePage(page, resp)
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		nextURL, err := resolvePageURL(resp, url, next)
+		if err != nil {
+			return err
+		}
+		url = nextURL
+	}
+	return nil
+}
+
+// resolvePageURL resolves the URL of the next page relative to the URL of the response, or the requested URL when the
+// response has no request, e.g. when it is returned by a custom transport.
+func resolvePageURL(resp *http.Response, requested string, next string) (string, error) {
+	var base *url.URL
+	if resp.Request != nil && resp.Request.URL != nil {
+		base = resp.Request.URL
+	} else {
+		var err error
+		if base, err = url.Parse(requested); err != nil {
+			return "", err
+		}
+	}
+	nextURL, err := base.Parse(next)
+	if err != nil {
+		return "", err
+	}
+	return nextURL.String(), nil
+}
+
+// ParseLinkHeader parses the RFC 5988 Link headers of the response and returns the URLs by relation type, e.g.
+// "next", "prev" or "last". The URLs are returned as they appear in the header and may be relative.
+func ParseLinkHeader(resp *http.Response) map[string]string {
+	links := make(map[string]string)
+	for _, header := range resp.Header.Values("Link") {
+		for _, link := range splitLinks(header) {
+			link = strings.TrimSpace(link)
+			end := strings.Index(link, ">")
+			if !strings.HasPrefix(link, "<") || end < 0 {
+				continue
+			}
+			target := link[1:end]
+
+			for _, param := range strings.Split(link[end+1:], ";") {
+				key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+				if !found || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+					links[strings.ToLower(rel)] = target
+				}
+			}
+		}
+	}
+	return links
+}
+
+// splitLinks splits a Link header into its links, on commas that are not part of a URL or a quoted parameter value.
+func splitLinks(header string) []string {
+	var links []string
+	inURL, inQuotes := false, false
+	start := 0
+	for i, r := range header {
+		switch {
+		case inQuotes:
+			inQuotes = r != '"'
+		case inURL:
+			inURL = r != '>'
+		case r == '<':
+			inURL = true
+		case r == '"':
+			inQuotes = true
+		case r == ',':
+			links = append(links, header[start:i])
+			start = i + 1
+		}
+	}
+	return append(links, header[start:])
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Query().Get("page") {
+			case "", "1":
+				_, _ = fmt.Fprint(w, `{"items":["a","b"],"next":"/items?page=2"}`)
+			case "2":
+				_, _ = fmt.Fprint(w, `{"items":["c"],"next":""}`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+	)
+	defer srv.Close()
+
+	type page struct {
+		Items []string `json:"items"`
+		Next  string   `json:"next"`
+	}
+	client := NewRestClient("resource", false)
+
+	t.Run("should fetch all pages", func(t *testing.T) {
+		var items []string
+		err := Paginate(client, srv.URL+"/items", func(p page, resp *http.Response) (string, error) {
+			items = append(items, p.Items...)
+			return p.Next, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, items)
+	})
+	t.Run("should stop on handler errors", func(t *testing.T) {
+		calls := 0
+		err := Paginate(client, srv.URL+"/items", func(p page, resp *http.Response) (string, error) {
+			calls++
+			return "", errors.New("stop")
+		})
+		assert.EqualError(t, err, "stop")
+		assert.Equal(t, 1, calls)
+	})
+	t.Run("should propagate request errors", func(t *testing.T) {
+		err := Paginate(client, srv.URL+"/items?page=3", func(p page, resp *http.Response) (string, error) {
+			return p.Next, nil
+		})
+		var httpErr *HTTPError
+		assert.ErrorAs(t, err, &httpErr)
+	})
+	t.Run("should resolve relative next URLs when the response has no request", func(t *testing.T) {
+		transportClient := NewRestClientWithBaseURL("http://items").WithTransport(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			body := `{"items":["c"],"next":""}`
+			if req.URL.Query().Get("page") == "" {
+				body = `{"items":["a","b"],"next":"items?page=2"}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}))
+
+		var items []string
+		var urls []string
+		err := Paginate(transportClient, transportClient.ResolveURL("/api/items"), func(p page, resp *http.Response) (string, error) {
+			items = append(items, p.Items...)
+			return p.Next, nil
+		}, func(req *http.Request) {
+			urls = append(urls, req.URL.String())
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, items)
+		assert.Equal(t, []string{"http://items/api/items", "http://items/api/items?page=2"}, urls)
+	})
+}
+
+func TestParseLinkHeader(t *testing.T) {
+	t.Run("should parse the links by relation", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Add("Link", `<https://api.example.com/items?page=2>; rel="next", <https://api.example.com/items?page=5>; rel="last"`)
+		resp.Header.Add("Link", `</items?page=1>; title="first page"; rel="prev first"`)
+		assert.Equal(t, map[string]string{
+			"next":  "https://api.example.com/items?page=2",
+			"last":  "https://api.example.com/items?page=5",
+			"prev":  "/items?page=1",
+			"first": "/items?page=1",
+		}, ParseLinkHeader(resp))
+	})
+	t.Run("should not split links on commas in URLs or quoted values", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Add("Link", `<https://api/x?ids=1,2&page=2>; rel="next"; title="a, b", <https://api/x?ids=1,2&page=1>; rel="prev"`)
+		assert.Equal(t, map[string]string{
+			"next": "https://api/x?ids=1,2&page=2",
+			"prev": "https://api/x?ids=1,2&page=1",
+		}, ParseLinkHeader(resp))
+	})
+	t.Run("should return an empty map without Link header", func(t *testing.T) {
+		assert.Empty(t, ParseLinkHeader(&http.Response{Header: http.Header{}}))
+	})
+	t.Run("should follow Link headers with Paginate", func(t *testing.T) {
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Query().Get("page") == "" {
+					w.Header().Set("Link", `</items?page=2>; rel="next"`)
+					_, _ = fmt.Fprint(w, `["a"]`)
+					return
+				}
+				_, _ = fmt.Fprint(w, `["b"]`)
+			}),
+		)
+		defer srv.Close()
+
+		var items []string
+		err := Paginate(NewRestClient("resource", false), srv.URL+"/items", func(page []string, resp *http.Response) (string, error) {
+			items = append(items, page...)
+			return ParseLinkHeader(resp)["next"], nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b"}, items)
+	})
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+// PatchOperation is a single RFC 6902 JSON Patch operation. Op is one of "add", "remove", "replace", "move", "copy"
+// or "test", From is only used by "move" and "copy" and Value is not sent for "remove", "move" and "copy".
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value"`
+}
+
+// MarshalJSON only includes the value for operations using it, so that null, false and 0 can be sent as values.
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	type operation PatchOperation
+	switch o.Op {
+	case "remove", "move", "copy":
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+			From string `json:"from,omitempty"`
+		}{o.Op, o.Path, o.From})
+	}
+	return json.Marshal(operation(o))
+}
+
+// PATCHMergePatch performs a PATCH request to the specified URL sending the body as an RFC 7386 JSON Merge Patch with
+// the application/merge-patch+json content type. Fields set to null in the patch are removed from the resource.
+// Example:
+//
+//	response, err := client.PATCHMergePatch(client.ResolveURL("/api/v1/users/%s", userID), map[string]any{
+//		"name":     "john",
+//		"nickname": nil,
+//	})
+func (c *RestClient) PATCHMergePatch(url string, patch any, requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	reader, size, err := c.jsonBody(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.PATCHStream(url, mergePatchContentType, reader, size, requestModifier...)
+}
+
+// PATCHJSONPatch performs a PATCH request to the specified URL sending the operations as an RFC 6902 JSON Patch with
+// the application/json-patch+json content type.
+// Example:
+//
+//	response, err := client.PATCHJSONPatch(client.ResolveURL("/api/v1/users/%s", userID), []PatchOperation{
+//		{Op: "replace", Path: "/name", Value: "john"},
+//		{Op: "remove", Path: "/nickname"},
+//	})
+func (c *RestClient) PATCHJSONPatch(url string, operations []PatchOperation, requestModifier ...func(req *http.Request)) (*http.Response, error) {
+	if operations == nil {
+		operations = []PatchOperation{}
+	}
+	reader, size, err := c.jsonBody(operations)
+	if err != nil {
+		return nil, err
+	}
+	return c.PATCHStream(url, jsonPatchContentType, reader, size, requestModifier...)
+}
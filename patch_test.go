@@ -0,0 +1,54 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatchHelpers(t *testing.T) {
+	t.Run("should send a JSON merge patch", func(t *testing.T) {
+		mock := NewMockTransport().Respond(http.MethodPatch, "/users/1", MockResponse{StatusCode: http.StatusOK})
+		client := NewRestClientWithBaseURL("http://users").WithTransport(mock)
+
+		resp, err := client.PATCHMergePatch(client.ResolveURL("/users/1"), map[string]any{"name": "john", "nickname": nil})
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		requests := mock.Requests()
+		assert.Len(t, requests, 1)
+		assert.Equal(t, "application/merge-patch+json", requests[0].Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"name":"john","nickname":null}`, string(requests[0].Body))
+	})
+	t.Run("should send a JSON patch", func(t *testing.T) {
+		mock := NewMockTransport().Respond(http.MethodPatch, "/users/1", MockResponse{StatusCode: http.StatusOK})
+		client := NewRestClientWithBaseURL("http://users").WithTransport(mock)
+
+		_, err := client.PATCHJSONPatch(client.ResolveURL("/users/1"), []PatchOperation{
+			{Op: "replace", Path: "/active", Value: false},
+			{Op: "add", Path: "/nickname", Value: nil},
+			{Op: "remove", Path: "/email"},
+			{Op: "move", From: "/a", Path: "/b"},
+		})
+		assert.Nil(t, err)
+
+		requests := mock.Requests()
+		assert.Len(t, requests, 1)
+		assert.Equal(t, "application/json-patch+json", requests[0].Header.Get("Content-Type"))
+		assert.JSONEq(t, `[
+			{"op":"replace","path":"/active","value":false},
+			{"op":"add","path":"/nickname","value":null},
+			{"op":"remove","path":"/email"},
+			{"op":"move","path":"/b","from":"/a"}
+		]`, string(requests[0].Body))
+	})
+	t.Run("should send an empty JSON patch for no operations", func(t *testing.T) {
+		mock := NewMockTransport().Respond(http.MethodPatch, "/users/1", MockResponse{StatusCode: http.StatusOK})
+		client := NewRestClientWithBaseURL("http://users").WithTransport(mock)
+
+		_, err := client.PATCHJSONPatch(client.ResolveURL("/users/1"), nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "[]", string(mock.Requests()[0].Body))
+	})
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+const defaultPingPath = "/"
+
+// WithPingPath sets the path requested by Ping, "/" by default.
+func (c *RestClient) WithPingPath(path string) *RestClient {
+	c.pingPath = path
+	return c
+}
+
+// Ping sends a HEAD request to the ping path and returns nil if the service responds with a 2xx status, an
+// *HTTPError with the response status otherwise, or the error if the request failed.
+// Example:
+//
+//	if err := client.Ping(ctx); err != nil {
+//		return fmt.Errorf("users service not reachable: %w", err)
+//	}
+func (c *RestClient) Ping(ctx context.Context) error {
+	path := c.pingPath
+	if path == "" {
+		path = defaultPingPath
+	}
+
+	resp, err := c.HEAD(c.baseURL()+path, func(req *http.Request) {
+		*req = *req.WithContext(ctx)
+	})
+	if err != nil {
+		return err
+	}
+	if !IsSuccess(resp) {
+		return newStatusError(resp)
+	}
+	return resp.Body.Close()
+}
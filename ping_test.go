@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPing(t *testing.T) {
+	t.Run("should return nil when the service responds with a 2xx status", func(t *testing.T) {
+		var method, path string
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method, path = r.Method, r.URL.Path
+			}),
+		)
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL)
+		assert.Nil(t, client.Ping(context.Background()))
+		assert.Equal(t, http.MethodHead, method)
+		assert.Equal(t, "/", path)
+	})
+	t.Run("should use the configured ping path", func(t *testing.T) {
+		var path string
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+			}),
+		)
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL).WithPingPath("/health")
+		assert.Nil(t, client.Ping(context.Background()))
+		assert.Equal(t, "/health", path)
+	})
+	t.Run("should send ping paths with percent signs as is", func(t *testing.T) {
+		var path string
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.EscapedPath()
+			}),
+		)
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL).WithPingPath("/health%2Fcheck")
+		assert.Nil(t, client.Ping(context.Background()))
+		assert.Equal(t, "/health%2Fcheck", path)
+	})
+	t.Run("should return an HTTPError for other statuses", func(t *testing.T) {
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}),
+		)
+		defer srv.Close()
+
+		err := NewRestClientWithBaseURL(srv.URL).Ping(context.Background())
+		var httpErr *HTTPError
+		assert.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+	})
+	t.Run("should return the error when the context is cancelled", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer srv.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, NewRestClientWithBaseURL(srv.URL).Ping(ctx), context.Canceled)
+	})
+}
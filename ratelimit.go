@@ -0,0 +1,40 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrRateLimited is returned by a client in non-blocking rate limit mode when no request can be sent right now.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// WithRateLimit limits the client to rps requests per second with bursts of up to burst requests, across all methods.
+// Requests block until they are allowed to be sent or the request context is done, use WithRateLimitNonBlocking to
+// fail with ErrRateLimited instead.
+func (c *RestClient) WithRateLimit(rps float64, burst int) *RestClient {
+	c.limiter = rate.NewLimiter(rate.Limit(rps), burst)
+	return c
+}
+
+// WithRateLimitNonBlocking makes requests fail immediately with ErrRateLimited when the rate limit is exceeded instead
+// of waiting.
+func (c *RestClient) WithRateLimitNonBlocking() *RestClient {
+	c.limitNonBlocking = true
+	return c
+}
+
+// waitRateLimit waits until the request is allowed by the rate limit, if any.
+func (c *RestClient) waitRateLimit(req *http.Request) error {
+	if c.limiter == nil {
+		return nil
+	}
+	if c.limitNonBlocking {
+		if !c.limiter.Allow() {
+			return ErrRateLimited
+		}
+		return nil
+	}
+	return c.limiter.Wait(req.Context())
+}
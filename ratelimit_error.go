@@ -0,0 +1,83 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit is the rate limit state reported by a server in X-RateLimit-* and Retry-After headers. Values that are
+// not reported are -1 for Limit and Remaining and zero for Reset and RetryAfter.
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window, from X-RateLimit-Limit.
+	Limit int
+	// Remaining is the number of requests left in the current window, from X-RateLimit-Remaining.
+	Remaining int
+	// Reset is when the current window ends, from X-RateLimit-Reset given as unix time or in seconds from now.
+	Reset time.Time
+	// RetryAfter is how long to wait before sending another request, from Retry-After.
+	RetryAfter time.Duration
+}
+
+// RateLimitError is returned instead of an *HTTPError for 429 Too Many Requests responses, errors.As with an
+// *HTTPError matches it as well.
+// Example:
+//
+//	var rateLimitErr *RateLimitError
+//	if errors.As(err, &rateLimitErr) {
+//		time.Sleep(time.Until(rateLimitErr.Reset))
+//	}
+type RateLimitError struct {
+	*HTTPError
+	RateLimit
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited: %s", e.HTTPError)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.HTTPError
+}
+
+// ParseRateLimit returns the rate limit state from the headers of the response, so clients can slow down before
+// being rate limited.
+func ParseRateLimit(resp *http.Response) RateLimit {
+	now := time.Now()
+	rateLimit := RateLimit{
+		Limit:     headerInt(resp.Header, "X-RateLimit-Limit"),
+		Remaining: headerInt(resp.Header, "X-RateLimit-Remaining"),
+	}
+	if reset := headerInt(resp.Header, "X-RateLimit-Reset"); reset >= 0 {
+		// large values are unix timestamps, small ones are seconds until the reset
+		if reset > 1_000_000_000 {
+			rateLimit.Reset = time.Unix(int64(reset), 0)
+		} else {
+			rateLimit.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+		rateLimit.RetryAfter = retryAfter
+	}
+	return rateLimit
+}
+
+// headerInt returns the header value as int or -1 if it is missing or invalid.
+func headerInt(header http.Header, key string) int {
+	value, err := strconv.Atoi(header.Get(key))
+	if err != nil {
+		return -1
+	}
+	return value
+}
+
+// newStatusError reads and closes the response body and returns a *RateLimitError for 429 responses and an
+// *HTTPError otherwise.
+func newStatusError(resp *http.Response) error {
+	httpErr := newHTTPError(resp)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{HTTPError: httpErr, RateLimit: ParseRateLimit(resp)}
+	}
+	return httpErr
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitError(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-RateLimit-Limit", "100")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte("slow down"))
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should return a RateLimitError with the parsed headers", func(t *testing.T) {
+		_, err := Get[map[string]string](NewRestClient("resource", false), srv.URL)
+
+		var rateLimitErr *RateLimitError
+		assert.ErrorAs(t, err, &rateLimitErr)
+		assert.Equal(t, 100, rateLimitErr.Limit)
+		assert.Equal(t, 0, rateLimitErr.Remaining)
+		assert.True(t, reset.Equal(rateLimitErr.Reset))
+		assert.Equal(t, 30*time.Second, rateLimitErr.RetryAfter)
+		assert.Equal(t, "rate limited: unexpected status: 429 Too Many Requests: slow down", err.Error())
+
+		var httpErr *HTTPError
+		assert.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+	})
+	t.Run("should return a RateLimitError with WithErrorOnStatus", func(t *testing.T) {
+		_, err := NewRestClient("resource", false).WithErrorOnStatus().GET(srv.URL)
+		var rateLimitErr *RateLimitError
+		assert.ErrorAs(t, err, &rateLimitErr)
+	})
+	t.Run("should parse the rate limit of any response", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{
+			"X-Ratelimit-Remaining": []string{"5"},
+			"X-Ratelimit-Reset":     []string{"10"},
+		}}
+		rateLimit := ParseRateLimit(resp)
+		assert.Equal(t, -1, rateLimit.Limit)
+		assert.Equal(t, 5, rateLimit.Remaining)
+		assert.WithinDuration(t, time.Now().Add(10*time.Second), rateLimit.Reset, time.Second)
+		assert.Zero(t, rateLimit.RetryAfter)
+	})
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	t.Run("should block until a request is allowed", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithRateLimit(20, 1)
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			_, err := client.GET(srv.URL)
+			assert.Nil(t, err)
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	})
+	t.Run("should honor the request context while waiting", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithRateLimit(0.1, 1)
+		_, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = client.GET(srv.URL, func(req *http.Request) {
+			*req = *req.WithContext(ctx)
+		})
+		assert.Error(t, err)
+	})
+	t.Run("should return ErrRateLimited in non-blocking mode", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithRateLimit(0.1, 1).WithRateLimitNonBlocking()
+		_, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		_, err = client.GET(srv.URL)
+		assert.ErrorIs(t, err, ErrRateLimited)
+	})
+}
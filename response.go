@@ -0,0 +1,65 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// BufferResponse reads the response body into memory and replaces it with a buffered copy, so the body can be
+// inspected, e.g. for logging, and still be decoded afterwards. Calling BufferResponse again returns the same bytes
+// and rewinds the body to the start.
+// Example:
+//
+//	data, err := BufferResponse(resp)
+//	if err != nil {
+//		return err
+//	}
+//	log.Printf("response: %s", data)
+//	user, err := Decode[User](resp)
+func BufferResponse(resp *http.Response) ([]byte, error) {
+	if buffered, ok := resp.Body.(*bufferedBody); ok {
+		buffered.Reset(buffered.data)
+		return buffered.data, nil
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	closeErr := resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if closeErr != nil {
+		return nil, closeErr
+	}
+	resp.Body = newBufferedBody(data)
+	return data, nil
+}
+
+// bufferedBody is a response body read into memory.
+type bufferedBody struct {
+	*bytes.Reader
+	data []byte
+}
+
+func newBufferedBody(data []byte) *bufferedBody {
+	return &bufferedBody{Reader: bytes.NewReader(data), data: data}
+}
+
+func (b *bufferedBody) Close() error {
+	return nil
+}
+
+// IsSuccess returns true if the response has a 2xx status code.
+func IsSuccess(resp *http.Response) bool {
+	return resp.StatusCode >= 200 && resp.StatusCode <= 299
+}
+
+// IsClientError returns true if the response has a 4xx status code.
+func IsClientError(resp *http.Response) bool {
+	return resp.StatusCode >= 400 && resp.StatusCode <= 499
+}
+
+// IsServerError returns true if the response has a 5xx status code.
+func IsServerError(resp *http.Response) bool {
+	return resp.StatusCode >= 500 && resp.StatusCode <= 599
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBufferResponse(t *testing.T) {
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"john"}`))
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should return the body and still allow decoding it", func(t *testing.T) {
+		resp, err := NewRestClient("resource", false).GET(srv.URL)
+		assert.Nil(t, err)
+
+		data, err := BufferResponse(resp)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"name":"john"}`, string(data))
+
+		got, err := Decode[map[string]string](resp)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"name": "john"}, got)
+	})
+	t.Run("should rewind the body when called again", func(t *testing.T) {
+		resp, err := NewRestClient("resource", false).GET(srv.URL)
+		assert.Nil(t, err)
+
+		_, err = BufferResponse(resp)
+		assert.Nil(t, err)
+		_, _ = io.ReadAll(resp.Body)
+
+		data, err := BufferResponse(resp)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"name":"john"}`, string(data))
+		rest, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, `{"name":"john"}`, string(rest))
+	})
+	t.Run("should return read errors", func(t *testing.T) {
+		resp := &http.Response{Body: io.NopCloser(io.MultiReader(strings.NewReader("abc"), errReader{}))}
+		_, err := BufferResponse(resp)
+		assert.ErrorIs(t, err, errRead)
+	})
+}
+
+var errRead = errors.New("read failed")
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errRead
+}
+
+func TestStatusHelpers(t *testing.T) {
+	tests := []struct {
+		statusCode  int
+		success     bool
+		clientError bool
+		serverError bool
+	}{
+		{statusCode: http.StatusContinue},
+		{statusCode: http.StatusOK, success: true},
+		{statusCode: http.StatusNoContent, success: true},
+		{statusCode: 299, success: true},
+		{statusCode: http.StatusNotModified},
+		{statusCode: http.StatusBadRequest, clientError: true},
+		{statusCode: 499, clientError: true},
+		{statusCode: http.StatusInternalServerError, serverError: true},
+		{statusCode: 599, serverError: true},
+		{statusCode: 600},
+	}
+	for _, tt := range tests {
+		t.Run("should classify status "+http.StatusText(tt.statusCode), func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.statusCode}
+			assert.Equal(t, tt.success, IsSuccess(resp), tt.statusCode)
+			assert.Equal(t, tt.clientError, IsClientError(resp), tt.statusCode)
+			assert.Equal(t, tt.serverError, IsServerError(resp), tt.statusCode)
+		})
+	}
+}
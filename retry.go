@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultMaxRetryAfter caps the delay requested by a Retry-After header unless set with WithMaxRetryAfter.
+const defaultMaxRetryAfter = 30 * time.Second
+
+type retryConfig struct {
+	maxAttempts   int
+	baseDelay     time.Duration
+	nonIdempotent bool
+	maxRetryAfter time.Duration
+}
+
+// WithRetry enables retries of idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE and requests with an
+// Idempotency-Key header) on connection errors and on 429, 502, 503 and 504 responses. maxAttempts is the total number
+// of attempts including the first one, the delay between attempts grows exponentially from baseDelay with added jitter.
+// When a 429 or 503 response has a Retry-After header the next attempt waits as long as requested instead, up to the
+// maximum set with WithMaxRetryAfter. Requests with a streamed body that can't be read again, e.g. a file passed to
+// PUTStream, are sent only once, while bodies passed as JSON, []byte, bytes.Reader or strings.Reader are retried.
+func (c *RestClient) WithRetry(maxAttempts int, baseDelay time.Duration) *RestClient {
+	c.retry.maxAttempts = maxAttempts
+	c.retry.baseDelay = baseDelay
+	return c
+}
+
+// WithRetryNonIdempotent allows retrying non-idempotent requests (POST, PATCH) as well, only use this when the
+// backend can safely handle the same request more than once.
+func (c *RestClient) WithRetryNonIdempotent() *RestClient {
+	c.retry.nonIdempotent = true
+	return c
+}
+
+// WithMaxRetryAfter sets the maximum delay before a retry requested by a Retry-After header, 30 seconds by default.
+func (c *RestClient) WithMaxRetryAfter(max time.Duration) *RestClient {
+	c.retry.maxRetryAfter = max
+	return c
+}
+
+// shouldRetry returns true if the request is eligible for retries, because its method is idempotent or it has an
+// idempotency key, and its body can be sent again.
+func (r *retryConfig) shouldRetry(req *http.Request) bool {
+	if r.maxAttempts <= 1 {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// a streamed body is not buffered in memory, so it can only be sent once
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return r.nonIdempotent || req.Header.Get(idempotencyKeyHeader) != ""
+}
+
+// backoff returns the delay before the given attempt, attempt starts at 1 for the first retry.
+func (r *retryConfig) backoff(attempt int) time.Duration {
+	delay := r.baseDelay << (attempt - 1)
+	if delay <= 0 {
+		return 0
+	}
+	// add jitter so concurrent clients don't retry in lockstep
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// doWithRetry sends the request and retries it according to the retry configuration.
+func (c *RestClient) doWithRetry(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.send(req)
+		if err == nil && !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		if attempt >= c.retry.maxAttempts || req.Context().Err() != nil {
+			if err != nil {
+				return nil, fmt.Errorf("request failed after %d attempts: %w", attempt, err)
+			}
+			return resp, nil
+		}
+		delay := c.retry.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := c.retry.retryAfter(resp); ok {
+				delay = retryAfter
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, fmt.Errorf("request failed after %d attempts: %w", attempt, err)
+		}
+	}
+}
+
+// isRetryableStatus returns true for status codes that indicate a transient failure.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the delay requested by the Retry-After header of a 429 or 503 response, capped by the maximum.
+func (r *retryConfig) retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	if !ok {
+		return 0, false
+	}
+	maxDelay := r.maxRetryAfter
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxRetryAfter
+	}
+	return min(delay, maxDelay), true
+}
+
+// parseRetryAfter parses a Retry-After header value given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the given duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRetry(t *testing.T) {
+	t.Run("should retry idempotent requests on 503", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		defer srv.Close()
+
+		client := NewRestClient("resource", false).WithRetry(3, time.Millisecond)
+		resp, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+	t.Run("should replay the body on each attempt", func(t *testing.T) {
+		var calls int32
+		var bodies []string
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				if atomic.AddInt32(&calls, 1) < 2 {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		defer srv.Close()
+
+		client := NewRestClient("resource", false).WithRetry(3, time.Millisecond)
+		resp, err := client.PUT(srv.URL, map[string]string{"name": "john"})
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{`{"name":"john"}`, `{"name":"john"}`}, bodies)
+	})
+	t.Run("should send streamed bodies only once", func(t *testing.T) {
+		var calls int32
+		var body string
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}),
+		)
+		defer srv.Close()
+
+		client := NewRestClient("resource", false).WithRetry(3, time.Millisecond)
+		stream := io.MultiReader(strings.NewReader("large "), strings.NewReader("file"))
+		resp, err := client.PUTStream(srv.URL, "application/octet-stream", stream, -1)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		assert.Equal(t, "large file", body)
+
+		atomic.StoreInt32(&calls, 0)
+		_, err = client.PUTStream(srv.URL, "application/octet-stream", strings.NewReader("small"), -1)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+	t.Run("should not retry POST unless opted in", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}),
+		)
+		defer srv.Close()
+
+		client := NewRestClient("resource", false).WithRetry(3, time.Millisecond)
+		resp, err := client.POST(srv.URL, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		atomic.StoreInt32(&calls, 0)
+		client.WithRetryNonIdempotent()
+		resp, err = client.POST(srv.URL, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+	t.Run("should report the number of attempts on connection errors", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		srv.Close()
+
+		client := NewRestClient("resource", false).WithRetry(2, time.Millisecond)
+		_, err := client.GET(srv.URL)
+		assert.ErrorContains(t, err, "after 2 attempts")
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Run("should parse delta seconds and HTTP dates", func(t *testing.T) {
+		now := time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC)
+		tests := map[string]time.Duration{
+			"2":                             2 * time.Second,
+			"0":                             0,
+			"-1":                            0,
+			"Thu, 01 Feb 2024 12:00:05 GMT": 5 * time.Second,
+			"Thu, 01 Feb 2024 11:59:00 GMT": 0,
+		}
+		for value, expected := range tests {
+			delay, ok := parseRetryAfter(value, now)
+			assert.True(t, ok, value)
+			assert.Equal(t, expected, delay, value)
+		}
+		_, ok := parseRetryAfter("soon", now)
+		assert.False(t, ok)
+		_, ok = parseRetryAfter("", now)
+		assert.False(t, ok)
+	})
+	t.Run("should wait for the Retry-After delay of a 429 response", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(http.StatusTooManyRequests)
+				}
+			}),
+		)
+		defer srv.Close()
+
+		client := NewRestClient("resource", false).WithRetry(2, time.Millisecond).WithMaxRetryAfter(100 * time.Millisecond)
+		start := time.Now()
+		resp, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+		assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	})
+	t.Run("should cap the delay at the maximum", func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Header:     http.Header{"Retry-After": []string{"3600"}},
+		}
+		delay, ok := (&retryConfig{}).retryAfter(resp)
+		assert.True(t, ok)
+		assert.Equal(t, defaultMaxRetryAfter, delay)
+
+		resp.StatusCode = http.StatusBadGateway
+		_, ok = (&retryConfig{}).retryAfter(resp)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"net/http"
+)
+
+// RequestSigner signs requests, e.g. by computing an HMAC over the method, path, a timestamp and the body and
+// setting it as a header. SignRequest is called right before every attempt is sent, after the request modifiers ran
+// and the body was compressed, so the signature covers the bytes that are sent. The body can be read with
+// req.GetBody without consuming it. Returning an error fails the request without sending it.
+type RequestSigner interface {
+	SignRequest(req *http.Request) error
+}
+
+// RequestSignerFunc signs a request, it implements RequestSigner.
+type RequestSignerFunc func(req *http.Request) error
+
+// SignRequest calls f(req).
+func (f RequestSignerFunc) SignRequest(req *http.Request) error {
+	return f(req)
+}
+
+// WithRequestSigner sets the signer used to sign every request.
+// Example:
+//
+//	client.WithRequestSigner(RequestSignerFunc(func(req *http.Request) error {
+//		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+//		mac := hmac.New(sha256.New, secret)
+//		mac.Write([]byte(req.Method + "\n" + req.URL.Path + "\n" + timestamp + "\n"))
+//		if req.GetBody != nil {
+//			body, err := req.GetBody()
+//			if err != nil {
+//				return err
+//			}
+//			defer body.Close()
+//			if _, err := io.Copy(mac, body); err != nil {
+//				return err
+//			}
+//		}
+//		req.Header.Set("X-Timestamp", timestamp)
+//		req.Header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+//		return nil
+//	}))
+func (c *RestClient) WithRequestSigner(signer RequestSigner) *RestClient {
+	c.signer = signer
+	return c
+}
+
+// signRequest signs the request with the signer, if any, making sure the body can be read with GetBody first.
+func (c *RestClient) signRequest(req *http.Request) error {
+	if c.signer == nil {
+		return nil
+	}
+	if req.GetBody == nil {
+		// buffers the body and sets GetBody
+		if _, err := peekBody(req); err != nil {
+			return err
+		}
+	}
+	return c.signer.SignRequest(req)
+}
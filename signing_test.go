@@ -0,0 +1,93 @@
+package client
+
+import (
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRequestSigner(t *testing.T) {
+	secret := []byte("secret")
+	sign := func(method, path string, body []byte) string {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(method + "\n" + path + "\n"))
+		mac.Write(body)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	signer := RequestSignerFunc(func(req *http.Request) error {
+		var body []byte
+		if req.GetBody != nil {
+			reader, err := req.GetBody()
+			if err != nil {
+				return err
+			}
+			defer reader.Close()
+			body, _ = io.ReadAll(reader)
+		}
+		req.Header.Set("X-Signature", sign(req.Method, req.URL.Path, body))
+		return nil
+	})
+
+	var valid bool
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			valid = r.Header.Get("X-Signature") == sign(r.Method, r.URL.Path, body)
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should sign the request after the modifiers ran", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithRequestSigner(signer)
+		_, err := client.POST(srv.URL+"/users", map[string]string{"name": "john"}, func(req *http.Request) {
+			req.URL.Path = "/users/modified"
+		})
+		assert.Nil(t, err)
+		assert.True(t, valid)
+	})
+	t.Run("should sign bodies without GetBody", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithRequestSigner(signer)
+		_, err := client.POSTRaw(srv.URL+"/", "text/plain", io.NopCloser(strings.NewReader("data")))
+		assert.Nil(t, err)
+		assert.True(t, valid)
+	})
+	t.Run("should sign the compressed body", func(t *testing.T) {
+		var signed []byte
+		client := NewRestClient("resource", false).
+			WithRequestCompression(0).
+			WithRequestSigner(RequestSignerFunc(func(req *http.Request) error {
+				body, _ := req.GetBody()
+				signed, _ = io.ReadAll(body)
+				return nil
+			}))
+		_, err := client.POST(srv.URL, strings.Repeat("a", 100))
+		assert.Nil(t, err)
+		reader, err := gzip.NewReader(strings.NewReader(string(signed)))
+		assert.Nil(t, err)
+		plain, _ := io.ReadAll(reader)
+		assert.Equal(t, `"`+strings.Repeat("a", 100)+`"`, string(plain))
+	})
+	t.Run("should not send the request when signing fails", func(t *testing.T) {
+		called := false
+		client := NewRestClient("resource", false).
+			WithTransport(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				called = true
+				return nil, errors.New("unexpected")
+			})).
+			WithRequestSigner(RequestSignerFunc(func(req *http.Request) error {
+				return errors.New("no key")
+			}))
+		_, err := client.GET(srv.URL)
+		assert.EqualError(t, err, "no key")
+		assert.False(t, called)
+	})
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// WithSingleFlight collapses concurrent GET requests for the same URL with the same headers into a single request,
+// every caller gets its own copy of the response. This reduces the load on the backend when many goroutines request
+// the same resource at once, e.g. on startup. The response body is read into memory, and all callers share the
+// outcome of the request sent, including it being cancelled by its context. A caller whose own context is done or
+// whose timeout expires stops waiting for the shared response.
+func (c *RestClient) WithSingleFlight() *RestClient {
+	c.flight = &singleflight.Group{}
+	return c
+}
+
+// doSingleFlight sends a GET request, sharing the response with identical concurrent requests.
+func (c *RestClient) doSingleFlight(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if timeout := c.requestTimeout(req); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	results := c.flight.DoChan(requestKey(req), func() (interface{}, error) {
+		resp, err := c.doGET(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := BufferResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		return &cacheEntry{
+			status:     resp.Status,
+			statusCode: resp.StatusCode,
+			proto:      resp.Proto,
+			header:     resp.Header,
+			body:       body,
+		}, nil
+	})
+	select {
+	case <-ctx.Done():
+		return nil, classifyError(ctx.Err())
+	case result := <-results:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(*cacheEntry).response(req), nil
+	}
+}
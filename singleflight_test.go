@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSingleFlight(t *testing.T) {
+	t.Run("should share one response between concurrent identical GET requests", func(t *testing.T) {
+		var hits atomic.Int32
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			<-release
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL).WithSingleFlight()
+
+		const callers = 5
+		var wg sync.WaitGroup
+		bodies := make([]string, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				resp, err := client.GET(srv.URL + "/data")
+				if !assert.Nil(t, err) {
+					return
+				}
+				defer resp.Body.Close()
+				body, err := io.ReadAll(resp.Body)
+				assert.Nil(t, err)
+				bodies[i] = string(body)
+			}(i)
+		}
+		// give the other callers time to join the request in flight
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), hits.Load())
+		for _, body := range bodies {
+			assert.Equal(t, "ok", body)
+		}
+	})
+
+	t.Run("should not share responses between different URLs", func(t *testing.T) {
+		var hits atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			_, _ = w.Write([]byte(r.URL.Path))
+		}))
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL).WithSingleFlight()
+		for _, path := range []string{"/a", "/b"} {
+			resp, err := client.GET(srv.URL + path)
+			assert.Nil(t, err)
+			body, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			assert.Equal(t, path, string(body))
+		}
+		assert.Equal(t, int32(2), hits.Load())
+	})
+
+	t.Run("should not share non-GET requests", func(t *testing.T) {
+		var hits atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+		}))
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL).WithSingleFlight()
+		for i := 0; i < 2; i++ {
+			resp, err := client.DELETE(srv.URL + "/a")
+			assert.Nil(t, err)
+			_ = resp.Body.Close()
+		}
+		assert.Equal(t, int32(2), hits.Load())
+	})
+	t.Run("should not share responses between requests with different headers", func(t *testing.T) {
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+			_, _ = w.Write([]byte(r.Header.Get("Cookie")))
+		}))
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL).WithSingleFlight()
+		var wg sync.WaitGroup
+		bodies := make([]string, 2)
+		for i, cookie := range []string{"session=alice", "session=bob"} {
+			wg.Add(1)
+			go func(i int, cookie string) {
+				defer wg.Done()
+				resp, err := client.GET(srv.URL, func(req *http.Request) {
+					req.Header.Set("Cookie", cookie)
+				})
+				if !assert.Nil(t, err) {
+					return
+				}
+				body, _ := io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+				bodies[i] = string(body)
+			}(i, cookie)
+		}
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		assert.Equal(t, []string{"session=alice", "session=bob"}, bodies)
+	})
+
+	t.Run("should stop waiting when the context or timeout of a caller expires", func(t *testing.T) {
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer srv.Close()
+		defer close(release)
+
+		client := NewRestClientWithBaseURL(srv.URL).WithSingleFlight()
+		go func() {
+			_, _ = client.GET(srv.URL)
+		}()
+		time.Sleep(50 * time.Millisecond)
+
+		start := time.Now()
+		_, err := client.GET(srv.URL, WithRequestTimeout(100*time.Millisecond))
+		assert.ErrorIs(t, err, ErrTimeout)
+		assert.Less(t, time.Since(start), time.Second)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start = time.Now()
+		_, err = client.GET(srv.URL, func(req *http.Request) {
+			*req = *req.WithContext(ctx)
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
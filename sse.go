@@ -0,0 +1,194 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	eventStreamContentType = "text/event-stream"
+	lastEventIDHeader      = "Last-Event-ID"
+	// defaultEventStreamRetry is the delay before reconnecting unless the server sets another one with a retry field.
+	defaultEventStreamRetry = 3 * time.Second
+)
+
+// ServerSentEvent is an event received from a Server-Sent Events stream.
+type ServerSentEvent struct {
+	// ID is the last event ID set by the stream, it is sent in the Last-Event-ID header when reconnecting.
+	ID string
+	// Event is the event type, "message" if not set by the server.
+	Event string
+	// Data is the event data, the data lines joined with newlines.
+	Data string
+	// Retry is the reconnection delay requested by the event, zero if not set.
+	Retry time.Duration
+}
+
+// SubscribeEvents opens a Server-Sent Events stream with a GET request to the URL and calls handler with each event
+// until ctx is cancelled or handler returns an error. When the stream ends or the connection fails, and when the
+// server responds with a 429, 502, 503 or 504 status, it reconnects after the delay requested by the server with a
+// retry field or a Retry-After header, 3 seconds by default, sending the ID of the last event in the Last-Event-ID
+// header.
+// It returns the context error when ctx is done, the handler error, nil when the server responds with 204 No Content
+// and an *HTTPError for other error responses. The client timeout is not applied to the stream, use
+// WithRequestTimeout to set one.
+// Example:
+//
+//	err := client.SubscribeEvents(ctx, client.ResolveURL("/api/v1/events"), func(event ServerSentEvent) error {
+//		log.Printf("%s: %s", event.Event, event.Data)
+//		return nil
+//	})
+func (c *RestClient) SubscribeEvents(ctx context.Context, url string, handler func(event ServerSentEvent) error, requestModifier ...func(req *http.Request)) error {
+	stream := &eventStream{handler: handler, retry: defaultEventStreamRetry}
+	for {
+		reconnect, err := c.readEventStream(ctx, url, stream, requestModifier)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if !reconnect {
+			return err
+		}
+		delay := stream.retry
+		if stream.hasRetryAfter {
+			delay, stream.hasRetryAfter = stream.retryAfter, false
+		}
+		c.logf(slog.LevelWarn, "Reconnecting to event stream %s in %s: %s", url, delay, err)
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// eventStream is the state of an event stream kept across reconnections.
+type eventStream struct {
+	handler     func(event ServerSentEvent) error
+	lastEventID string
+	// eventID is the ID set by the id fields read so far, it becomes the last event ID at the end of an event.
+	eventID string
+	retry   time.Duration
+	// retryAfter is the delay requested by the Retry-After header of the last response, if hasRetryAfter is set.
+	retryAfter    time.Duration
+	hasRetryAfter bool
+}
+
+// readEventStream sends a request for the event stream and dispatches its events until it ends. It returns true if
+// the stream should be reconnected, together with the reason.
+func (c *RestClient) readEventStream(ctx context.Context, url string, stream *eventStream, requestModifier []func(req *http.Request)) (bool, error) {
+	modifiers := append([]func(req *http.Request){
+		func(req *http.Request) {
+			*req = *req.WithContext(ctx)
+			WithRequestTimeout(0)(req)
+			req.Header.Set("Accept", eventStreamContentType)
+			req.Header.Set("Cache-Control", "no-cache")
+			if stream.lastEventID != "" {
+				req.Header.Set(lastEventIDHeader, stream.lastEventID)
+			}
+		},
+	}, requestModifier...)
+	resp, err := c.GET(url, modifiers...)
+	if err != nil {
+		return errors.Is(err, ErrConnection) || errors.Is(err, ErrTimeout), err
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		return false, resp.Body.Close()
+	}
+	if !IsSuccess(resp) {
+		stream.retryAfter, stream.hasRetryAfter = c.retry.retryAfter(resp)
+		return isRetryableStatus(resp.StatusCode), newStatusError(resp)
+	}
+	defer resp.Body.Close()
+
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != eventStreamContentType {
+		return false, newContentTypeError(contentType, eventStreamContentType, resp.Body)
+	}
+
+	err = stream.read(bufio.NewReader(resp.Body))
+	var handlerErr *eventHandlerError
+	if errors.As(err, &handlerErr) {
+		return false, handlerErr.err
+	}
+	if err == nil {
+		err = io.EOF
+	}
+	return true, fmt.Errorf("event stream interrupted: %w", err)
+}
+
+// eventHandlerError distinguishes errors returned by the handler from errors reading the stream.
+type eventHandlerError struct {
+	err error
+}
+
+func (e *eventHandlerError) Error() string {
+	return e.err.Error()
+}
+
+// read parses the events of the stream and dispatches them to the handler until the end of the stream.
+func (s *eventStream) read(reader *bufio.Reader) error {
+	var event ServerSentEvent
+	var data strings.Builder
+	hasData := false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			// an incomplete event at the end of the stream is discarded
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		if line == "" {
+			s.lastEventID = s.eventID
+			if hasData {
+				event.ID = s.lastEventID
+				event.Data = data.String()
+				if event.Event == "" {
+					event.Event = "message"
+				}
+				if err := s.handler(event); err != nil {
+					return &eventHandlerError{err: err}
+				}
+			}
+			event = ServerSentEvent{}
+			data.Reset()
+			hasData = false
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				s.eventID = value
+			}
+		case "retry":
+			if millis, err := strconv.ParseUint(value, 10, 63); err == nil {
+				event.Retry = time.Duration(millis) * time.Millisecond
+				s.retry = event.Retry
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeEvents(t *testing.T) {
+	t.Run("should parse events", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte(": comment\n" +
+				"data: hello\n\n" +
+				"event: update\r\nid: 1\r\ndata: line 1\r\ndata:line 2\r\nretry: 1500\r\n\r\n" +
+				"id: 2\n\n" +
+				"data: {\"id\":3}\n\n"))
+		}))
+		defer srv.Close()
+
+		var events []ServerSentEvent
+		stop := errors.New("stop")
+		client := NewRestClientWithBaseURL(srv.URL)
+		err := client.SubscribeEvents(context.Background(), client.ResolveURL("/events"), func(event ServerSentEvent) error {
+			events = append(events, event)
+			if len(events) == 3 {
+				return stop
+			}
+			return nil
+		})
+		assert.Equal(t, stop, err)
+		assert.Equal(t, []ServerSentEvent{
+			{Event: "message", Data: "hello"},
+			{ID: "1", Event: "update", Data: "line 1\nline 2", Retry: 1500 * time.Millisecond},
+			{ID: "2", Event: "message", Data: `{"id":3}`},
+		}, events)
+	})
+	t.Run("should reconnect with the last event ID", func(t *testing.T) {
+		var connections atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			connection := connections.Add(1)
+			w.Header().Set("Content-Type", "text/event-stream")
+			if connection == 1 {
+				assert.Equal(t, "", r.Header.Get("Last-Event-ID"))
+				_, _ = w.Write([]byte("retry: 10\nid: 1\ndata: first\n\nid: 2\ndata: incomplete"))
+				return
+			}
+			assert.Equal(t, "1", r.Header.Get("Last-Event-ID"))
+			_, _ = w.Write([]byte("id: 2\ndata: second\n\n"))
+		}))
+		defer srv.Close()
+
+		var data []string
+		stop := errors.New("stop")
+		client := NewRestClientWithBaseURL(srv.URL)
+		err := client.SubscribeEvents(context.Background(), client.ResolveURL("/events"), func(event ServerSentEvent) error {
+			data = append(data, event.Data)
+			if len(data) == 2 {
+				return stop
+			}
+			return nil
+		})
+		assert.Equal(t, stop, err)
+		assert.Equal(t, []string{"first", "second"}, data)
+		assert.Equal(t, int32(2), connections.Load())
+	})
+	t.Run("should reconnect on retryable status codes", func(t *testing.T) {
+		var connections atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if connections.Add(1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err := client.SubscribeEvents(ctx, client.ResolveURL("/events"), func(event ServerSentEvent) error {
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), connections.Load())
+	})
+	t.Run("should return an HTTPError for other error responses", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL)
+		err := client.SubscribeEvents(context.Background(), client.ResolveURL("/events"), func(event ServerSentEvent) error {
+			return nil
+		})
+		var httpErr *HTTPError
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+	})
+	t.Run("should stop when the context is cancelled", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			for i := 0; ; i++ {
+				if _, err := fmt.Fprintf(w, "data: %d\n\n", i); err != nil {
+					return
+				}
+				w.(http.Flusher).Flush()
+				select {
+				case <-r.Context().Done():
+					return
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+		}))
+		defer srv.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var received atomic.Int32
+		client := NewRestClientWithBaseURL(srv.URL).WithTimeout(time.Millisecond)
+		err := client.SubscribeEvents(ctx, client.ResolveURL("/events"), func(event ServerSentEvent) error {
+			if received.Add(1) == 3 {
+				cancel()
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int32(3), received.Load())
+	})
+	t.Run("should not cache event streams", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: hello\n\n"))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}))
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL).WithResponseCache(time.Minute, 10).WithSingleFlight()
+		stop := errors.New("stop")
+		err := client.SubscribeEvents(context.Background(), client.ResolveURL("/events"), func(event ServerSentEvent) error {
+			assert.True(t, strings.HasPrefix(event.Data, "hello"))
+			return stop
+		})
+		assert.Equal(t, stop, err)
+	})
+	t.Run("should deliver events with capture mode on", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: hello\n\n"))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}))
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL).WithCapture()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stop := errors.New("stop")
+		var events []string
+		err := client.SubscribeEvents(ctx, client.ResolveURL("/events"), func(event ServerSentEvent) error {
+			events = append(events, event.Data)
+			return stop
+		})
+		assert.Equal(t, stop, err)
+		assert.Equal(t, []string{"hello"}, events)
+		assert.Equal(t, "text/event-stream", client.LastResponse().Header.Get("Content-Type"))
+	})
+}
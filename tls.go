@@ -0,0 +1,56 @@
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+// WithTLSConfig sets the TLS configuration used for HTTPS connections, for example to trust a custom CA pool with
+// RootCAs or to present client certificates. The config is cloned so later changes to it have no effect.
+// Example:
+//
+//	pool := x509.NewCertPool()
+//	pool.AppendCertsFromPEM(caPEM)
+//	client := NewRestClient("users", true).WithTLSConfig(&tls.Config{RootCAs: pool})
+func (c *RestClient) WithTLSConfig(config *tls.Config) *RestClient {
+	c.configureTransport(func(transport *http.Transport) {
+		transport.TLSClientConfig = config.Clone()
+	})
+	return c
+}
+
+// WithClientCertificate adds a certificate presented to servers that require mutual TLS.
+// Example:
+//
+//	cert, err := tls.LoadX509KeyPair("client.crt", "client.key")
+//	if err != nil {
+//		return err
+//	}
+//	client := NewRestClient("users", true).WithClientCertificate(cert)
+func (c *RestClient) WithClientCertificate(cert tls.Certificate) *RestClient {
+	c.configureTLS(func(config *tls.Config) {
+		config.Certificates = append(config.Certificates, cert)
+	})
+	return c
+}
+
+// WithInsecureSkipVerify disables verification of the server certificate, use it for local development against
+// self-signed certificates only, never in production. It can be combined with WithTLSConfig when called after it.
+func (c *RestClient) WithInsecureSkipVerify(skip bool) *RestClient {
+	c.configureTLS(func(config *tls.Config) {
+		config.InsecureSkipVerify = skip
+	})
+	return c
+}
+
+// configureTLS modifies a copy of the TLS configuration of the transport, creating one if there is none.
+func (c *RestClient) configureTLS(configure func(config *tls.Config)) {
+	c.configureTransport(func(transport *http.Transport) {
+		config := &tls.Config{}
+		if transport.TLSClientConfig != nil {
+			config = transport.TLSClientConfig.Clone()
+		}
+		configure(config)
+		transport.TLSClientConfig = config
+	})
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTLS(t *testing.T) {
+	t.Run("should trust the CA pool of the TLS config", func(t *testing.T) {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer srv.Close()
+
+		pool := x509.NewCertPool()
+		pool.AddCert(srv.Certificate())
+		client := NewRestClientWithBaseURL(srv.URL).WithTLSConfig(&tls.Config{RootCAs: pool})
+
+		resp, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		_ = resp.Body.Close()
+	})
+	t.Run("should fail without the CA in the pool", func(t *testing.T) {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer srv.Close()
+
+		client := NewRestClientWithBaseURL(srv.URL).WithTLSConfig(&tls.Config{RootCAs: x509.NewCertPool()})
+		_, err := client.GET(srv.URL)
+		assert.Error(t, err)
+	})
+	t.Run("should present the client certificate", func(t *testing.T) {
+		var peerCerts int
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			peerCerts = len(r.TLS.PeerCertificates)
+		}))
+		srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+		srv.StartTLS()
+		defer srv.Close()
+
+		pool := x509.NewCertPool()
+		pool.AddCert(srv.Certificate())
+		client := NewRestClientWithBaseURL(srv.URL).
+			WithTLSConfig(&tls.Config{RootCAs: pool}).
+			WithClientCertificate(srv.TLS.Certificates[0])
+
+		resp, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, 1, peerCerts)
+	})
+	t.Run("should not modify the default transport", func(t *testing.T) {
+		NewRestClient("resource", false).WithTLSConfig(&tls.Config{ServerName: "example"})
+		config := http.DefaultTransport.(*http.Transport).TLSClientConfig
+		assert.True(t, config == nil || config.ServerName == "")
+	})
+}
+
+func TestWithInsecureSkipVerify(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	t.Run("should accept self-signed certificates", func(t *testing.T) {
+		client := NewRestClientWithBaseURL(srv.URL).WithInsecureSkipVerify(true)
+		resp, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		_ = resp.Body.Close()
+	})
+	t.Run("should keep the rest of the TLS config", func(t *testing.T) {
+		client := NewRestClientWithBaseURL(srv.URL).
+			WithTLSConfig(&tls.Config{ServerName: "example"}).
+			WithInsecureSkipVerify(true)
+		config := client.HTTPClient.Transport.(*http.Transport).TLSClientConfig
+		assert.True(t, config.InsecureSkipVerify)
+		assert.Equal(t, "example", config.ServerName)
+	})
+	t.Run("should verify certificates when disabled again", func(t *testing.T) {
+		client := NewRestClientWithBaseURL(srv.URL).WithInsecureSkipVerify(true).WithInsecureSkipVerify(false)
+		_, err := client.GET(srv.URL)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTracer enables tracing of requests. A client span is created for every request attempt, recording the client
+// name, method, URL and status code, and the trace context is injected into the request headers using the global propagator,
+// e.g. as a W3C traceparent header.
+func (c *RestClient) WithTracer(tracer trace.Tracer) *RestClient {
+	c.tracer = tracer
+	return c
+}
+
+// traceRequest starts a span for the request and injects the trace context into the request headers.
+// The returned function ends the span and must be called with the result of the request.
+func (c *RestClient) traceRequest(req *http.Request) (*http.Request, func(resp *http.Response, err error)) {
+	if c.tracer == nil {
+		return req, func(*http.Response, error) {}
+	}
+
+	name := c.Name()
+	spanName := req.Method
+	if name != "" {
+		spanName = name + " " + req.Method
+	}
+	ctx, span := c.tracer.Start(req.Context(), spanName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLFull(req.URL.String()),
+			semconv.ServerAddress(req.URL.Hostname()),
+		),
+	)
+	if name != "" {
+		span.SetAttributes(semconv.PeerService(name))
+	}
+	req = req.WithContext(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	return req, func(resp *http.Response, err error) {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+}
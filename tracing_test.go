@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type recordingTracer struct {
+	noop.Tracer
+	spans []*recordingSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(opts...)
+	span := &recordingSpan{
+		name:       name,
+		attributes: config.Attributes(),
+		sc: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{1},
+			SpanID:     trace.SpanID{byte(len(t.spans) + 1)},
+			TraceFlags: trace.FlagsSampled,
+		}),
+	}
+	t.spans = append(t.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordingSpan struct {
+	noop.Span
+	name       string
+	attributes []attribute.KeyValue
+	status     codes.Code
+	sc         trace.SpanContext
+	ended      bool
+}
+
+func (s *recordingSpan) SpanContext() trace.SpanContext {
+	return s.sc
+}
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.attributes = append(s.attributes, kv...)
+}
+
+func (s *recordingSpan) SetStatus(code codes.Code, description string) {
+	s.status = code
+}
+
+func (s *recordingSpan) End(options ...trace.SpanEndOption) {
+	s.ended = true
+}
+
+func (s *recordingSpan) attribute(key attribute.Key) attribute.Value {
+	for _, kv := range s.attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestWithTracer(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	var traceparent string
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceparent = r.Header.Get("traceparent")
+			w.WriteHeader(http.StatusNotFound)
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should create a span per request and inject the trace context", func(t *testing.T) {
+		tracer := &recordingTracer{}
+		client := NewRestClient("resource", false).WithTracer(tracer)
+		_, err := client.GET(srv.URL + "/users")
+		assert.Nil(t, err)
+
+		assert.Len(t, tracer.spans, 1)
+		span := tracer.spans[0]
+		assert.Equal(t, "resource GET", span.name)
+		assert.Equal(t, "resource", span.attribute("peer.service").AsString())
+		assert.True(t, span.ended)
+		assert.Equal(t, codes.Error, span.status)
+		assert.Equal(t, "GET", span.attribute("http.request.method").AsString())
+		assert.Equal(t, srv.URL+"/users", span.attribute("url.full").AsString())
+		assert.Equal(t, int64(http.StatusNotFound), span.attribute("http.response.status_code").AsInt64())
+		assert.Equal(t, "00-01000000000000000000000000000000-0100000000000000-01", traceparent)
+	})
+	t.Run("should include the client name in the span", func(t *testing.T) {
+		tracer := &recordingTracer{}
+		client := NewRestClient("resource", false).WithName("users-api").WithTracer(tracer)
+		_, err := client.GET(srv.URL + "/users")
+		assert.Nil(t, err)
+
+		span := tracer.spans[0]
+		assert.Equal(t, "users-api GET", span.name)
+		assert.Equal(t, "users-api", span.attribute("peer.service").AsString())
+	})
+}
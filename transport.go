@@ -0,0 +1,181 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"time"
+)
+
+// RedirectPolicy configures how a RestClient follows redirects.
+type RedirectPolicy struct {
+	// MaxRedirects is the maximum number of redirects to follow, 0 disables following redirects so the 3xx response
+	// is returned as is.
+	MaxRedirects int
+}
+
+// WithCookieJar sets the cookie jar used to store cookies from responses and send them with subsequent requests.
+// A nil jar creates a new in-memory jar for this client.
+func (c *RestClient) WithCookieJar(jar http.CookieJar) *RestClient {
+	if jar == nil {
+		// cookiejar.New never returns an error with nil options
+		jar, _ = cookiejar.New(nil)
+	}
+	c.configureHTTPClient(func(httpClient *http.Client) {
+		httpClient.Jar = jar
+	})
+	return c
+}
+
+// WithRedirectPolicy sets how redirects are followed, by default up to 10 redirects are followed.
+// When the maximum number of redirects is reached the last 3xx response is returned without an error.
+// Sensitive headers like Authorization are always removed on redirects to a different host.
+func (c *RestClient) WithRedirectPolicy(policy RedirectPolicy) *RestClient {
+	c.configureHTTPClient(func(httpClient *http.Client) {
+		httpClient.CheckRedirect = policy.checkRedirect
+	})
+	return c
+}
+
+// checkRedirect implements http.Client.CheckRedirect for the policy.
+func (p RedirectPolicy) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) > p.MaxRedirects {
+		return http.ErrUseLastResponse
+	}
+	return nil
+}
+
+// sensitiveRedirectHeaders are removed from redirected requests to a different host than the original request.
+var sensitiveRedirectHeaders = []string{"Authorization", "Cookie"}
+
+// stripSensitiveHeadersOnRedirect returns a CheckRedirect function that removes sensitive headers when a redirect
+// points to a different host than the original request before calling checkRedirect, or applying the default
+// policy of http.Client if it is nil.
+func stripSensitiveHeadersOnRedirect(checkRedirect func(req *http.Request, via []*http.Request) error) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if req.URL.Host != via[0].URL.Host {
+			for _, header := range sensitiveRedirectHeaders {
+				req.Header.Del(header)
+			}
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+}
+
+// configureHTTPClient replaces the http.Client with a copy modified by configure, so neither http.DefaultClient nor a
+// client passed to WithHTTPClient are changed.
+func (c *RestClient) configureHTTPClient(configure func(httpClient *http.Client)) {
+	httpClient := *c.httpClient()
+	configure(&httpClient)
+	c.HTTPClient = &httpClient
+}
+
+// WithTransport sets the http.RoundTripper used to send requests, keeping the rest of the http.Client configuration.
+// It is useful to send requests through a custom transport or to return canned responses in tests.
+// Example:
+//
+//	client := NewRestClientWithBaseURL("http://users").WithTransport(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+//		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"id":"1"}`))}, nil
+//	}))
+func (c *RestClient) WithTransport(transport http.RoundTripper) *RestClient {
+	c.configureHTTPClient(func(httpClient *http.Client) {
+		httpClient.Transport = transport
+	})
+	return c
+}
+
+// WithProxy sends all requests through the proxy at proxyURL, for example "http://proxy.internal:3128".
+// If proxyURL is not a valid URL requests fail with the parse error instead of bypassing the proxy.
+func (c *RestClient) WithProxy(proxyURL string) *RestClient {
+	proxy := func(*http.Request) (*url.URL, error) {
+		return nil, fmt.Errorf("invalid proxy URL %q", proxyURL)
+	}
+	if u, err := url.Parse(proxyURL); err == nil && u.Host != "" {
+		proxy = http.ProxyURL(u)
+	}
+	c.configureTransport(func(transport *http.Transport) {
+		transport.Proxy = proxy
+	})
+	return c
+}
+
+// WithProxyFromEnvironment uses the proxy configured by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func (c *RestClient) WithProxyFromEnvironment() *RestClient {
+	c.configureTransport(func(transport *http.Transport) {
+		transport.Proxy = http.ProxyFromEnvironment
+	})
+	return c
+}
+
+// WithMaxIdleConns sets the maximum number of idle connections kept open across all hosts, 0 means no limit.
+func (c *RestClient) WithMaxIdleConns(n int) *RestClient {
+	c.configureTransport(func(transport *http.Transport) {
+		transport.MaxIdleConns = n
+	})
+	return c
+}
+
+// WithMaxIdleConnsPerHost sets the maximum number of idle connections kept open per host, raise it for clients
+// sending many concurrent requests to the same service to avoid opening new connections.
+// Example:
+//
+//	client := NewRestClient("users", true).WithMaxIdleConns(200).WithMaxIdleConnsPerHost(100)
+func (c *RestClient) WithMaxIdleConnsPerHost(n int) *RestClient {
+	c.configureTransport(func(transport *http.Transport) {
+		transport.MaxIdleConnsPerHost = n
+	})
+	return c
+}
+
+// WithIdleConnTimeout sets how long an idle connection is kept open before it is closed, 0 means no limit.
+func (c *RestClient) WithIdleConnTimeout(timeout time.Duration) *RestClient {
+	c.configureTransport(func(transport *http.Transport) {
+		transport.IdleConnTimeout = timeout
+	})
+	return c
+}
+
+// WithDisableKeepAlives closes connections after each request instead of reusing them.
+func (c *RestClient) WithDisableKeepAlives() *RestClient {
+	c.configureTransport(func(transport *http.Transport) {
+		transport.DisableKeepAlives = true
+	})
+	return c
+}
+
+// WithDisableCompression stops the transport from requesting gzip compressed responses with the Accept-Encoding
+// header. Responses the server compresses anyway are still decompressed.
+func (c *RestClient) WithDisableCompression() *RestClient {
+	c.configureTransport(func(transport *http.Transport) {
+		transport.DisableCompression = true
+	})
+	return c
+}
+
+// configureTransport replaces the transport with a copy modified by configure, starting from http.DefaultTransport
+// when none is set. A transport that is not an *http.Transport cannot be configured and is kept as is.
+func (c *RestClient) configureTransport(configure func(transport *http.Transport)) {
+	c.configureHTTPClient(func(httpClient *http.Client) {
+		roundTripper := httpClient.Transport
+		if roundTripper == nil {
+			roundTripper = http.DefaultTransport
+		}
+		transport, ok := roundTripper.(*http.Transport)
+		if !ok {
+			c.logf(slog.LevelWarn, "REST client for %s can not configure transport of type %T", c.resourceName, roundTripper)
+			return
+		}
+		transport = transport.Clone()
+		configure(transport)
+		httpClient.Transport = transport
+	})
+}
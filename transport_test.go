@@ -0,0 +1,250 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCookieJar(t *testing.T) {
+	var session string
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/login" {
+				http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/"})
+				return
+			}
+			if cookie, err := r.Cookie("session"); err == nil {
+				session = cookie.Value
+			}
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should store and send cookies", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithCookieJar(nil)
+		_, err := client.POST(srv.URL+"/login", nil)
+		assert.Nil(t, err)
+		_, err = client.GET(srv.URL + "/users")
+		assert.Nil(t, err)
+		assert.Equal(t, "abc", session)
+		assert.Nil(t, http.DefaultClient.Jar)
+	})
+	t.Run("should not modify the configured http client", func(t *testing.T) {
+		httpClient := &http.Client{}
+		client := NewRestClient("resource", false).WithHTTPClient(httpClient).WithCookieJar(nil)
+		assert.Nil(t, httpClient.Jar)
+		assert.NotNil(t, client.HTTPClient.Jar)
+	})
+}
+
+func TestWithRedirectPolicy(t *testing.T) {
+	var authorization string
+	target := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+		}),
+	)
+	defer target.Close()
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/cross-host":
+				http.Redirect(w, r, target.URL, http.StatusFound)
+			case "/twice":
+				http.Redirect(w, r, "/once", http.StatusFound)
+			case "/once":
+				http.Redirect(w, r, "/done", http.StatusFound)
+			}
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should not follow redirects when disabled", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithRedirectPolicy(RedirectPolicy{MaxRedirects: 0})
+		resp, err := client.GET(srv.URL + "/once")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+		assert.Equal(t, "/done", resp.Header.Get("Location"))
+	})
+	t.Run("should limit the number of redirects", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithRedirectPolicy(RedirectPolicy{MaxRedirects: 1})
+		resp, err := client.GET(srv.URL + "/twice")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+		assert.Equal(t, "/done", resp.Header.Get("Location"))
+
+		resp, err = client.GET(srv.URL + "/once")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+	t.Run("should strip the Authorization header on cross host redirects", func(t *testing.T) {
+		client := NewRestClient("resource", false).
+			WithRedirectPolicy(RedirectPolicy{MaxRedirects: 10}).
+			WithBearerToken("token")
+		resp, err := client.GET(srv.URL + "/cross-host")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, authorization)
+	})
+}
+
+func TestCrossHostRedirects(t *testing.T) {
+	var authorization, cookie string
+	target := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			cookie = r.Header.Get("Cookie")
+		}),
+	)
+	defer target.Close()
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/same-host" {
+				http.Redirect(w, r, "/done", http.StatusFound)
+				return
+			}
+			if r.URL.Path == "/done" {
+				authorization = r.Header.Get("Authorization")
+				cookie = r.Header.Get("Cookie")
+				return
+			}
+			http.Redirect(w, r, target.URL, http.StatusFound)
+		}),
+	)
+	defer srv.Close()
+
+	withCredentials := func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("Cookie", "session=abc")
+	}
+
+	t.Run("should strip sensitive headers on cross host redirects by default", func(t *testing.T) {
+		client := NewRestClient("resource", false)
+		resp, err := client.GET(srv.URL+"/cross-host", withCredentials)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, authorization)
+		assert.Empty(t, cookie)
+	})
+	t.Run("should keep sensitive headers on same host redirects", func(t *testing.T) {
+		client := NewRestClient("resource", false)
+		resp, err := client.GET(srv.URL+"/same-host", withCredentials)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "Bearer token", authorization)
+		assert.Equal(t, "session=abc", cookie)
+	})
+}
+
+func TestWithProxy(t *testing.T) {
+	t.Run("should send requests through the proxy", func(t *testing.T) {
+		var proxied string
+		proxy := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				proxied = r.URL.String()
+			}),
+		)
+		defer proxy.Close()
+
+		client := NewRestClient("resource", false).WithProxy(proxy.URL)
+		resp, err := client.GET("http://users.internal/users")
+		assert.Nil(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, "http://users.internal/users", proxied)
+	})
+	t.Run("should fail requests when the proxy URL is invalid", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithProxy("::invalid")
+		_, err := client.GET("http://users.internal/users")
+		assert.ErrorContains(t, err, `invalid proxy URL "::invalid"`)
+	})
+	t.Run("should use the proxy from the environment", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithProxyFromEnvironment()
+		assert.NotNil(t, client.HTTPClient.Transport.(*http.Transport).Proxy)
+	})
+}
+
+func TestConnectionPool(t *testing.T) {
+	t.Run("should configure the connection pool", func(t *testing.T) {
+		client := NewRestClient("resource", false).
+			WithMaxIdleConns(200).
+			WithMaxIdleConnsPerHost(100).
+			WithIdleConnTimeout(time.Minute)
+
+		transport := client.HTTPClient.Transport.(*http.Transport)
+		assert.Equal(t, 200, transport.MaxIdleConns)
+		assert.Equal(t, 100, transport.MaxIdleConnsPerHost)
+		assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+		assert.NotEqual(t, 100, http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost)
+	})
+	t.Run("should keep a custom round tripper", func(t *testing.T) {
+		transport := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, nil
+		})
+		client := NewRestClient("resource", false).
+			WithHTTPClient(&http.Client{Transport: transport}).
+			WithMaxIdleConnsPerHost(100)
+		assert.IsType(t, RoundTripperFunc(nil), client.HTTPClient.Transport)
+	})
+}
+
+func TestTransportOptions(t *testing.T) {
+	var acceptEncoding string
+	var closeConn bool
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			acceptEncoding, closeConn = r.Header.Get("Accept-Encoding"), r.Close
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("should request compressed responses and keep connections alive by default", func(t *testing.T) {
+		resp, err := NewRestClient("resource", false).GET(srv.URL)
+		assert.Nil(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, "gzip", acceptEncoding)
+		assert.False(t, closeConn)
+	})
+	t.Run("should disable keep alives and compression", func(t *testing.T) {
+		client := NewRestClient("resource", false).WithDisableKeepAlives().WithDisableCompression()
+		resp, err := client.GET(srv.URL)
+		assert.Nil(t, err)
+		_ = resp.Body.Close()
+		assert.Empty(t, acceptEncoding)
+		assert.True(t, closeConn)
+	})
+}
+
+func TestWithTransport(t *testing.T) {
+	t.Run("should send requests with the transport", func(t *testing.T) {
+		var got *http.Request
+		client := NewRestClientWithBaseURL("http://users").
+			WithTransport(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				got = req
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"id":"1"}`)),
+				}, nil
+			}))
+
+		user, err := Get[map[string]string](client, client.ResolveURL("/users/1"))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"id": "1"}, user)
+		assert.Equal(t, "http://users/users/1", got.URL.String())
+	})
+	t.Run("should keep the rest of the http client", func(t *testing.T) {
+		jar, _ := cookiejar.New(nil)
+		client := NewRestClient("resource", false).
+			WithHTTPClient(&http.Client{Jar: jar}).
+			WithTransport(http.DefaultTransport)
+		assert.Equal(t, jar, client.HTTPClient.Jar)
+		assert.Equal(t, http.DefaultTransport, client.HTTPClient.Transport)
+	})
+}
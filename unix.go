@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	unixScheme = "unix://"
+	// unixSocketHost is the host of the BaseURL of a client connected to a unix domain socket, the host is not used
+	// to connect but is sent in the Host header.
+	unixSocketHost = "unix"
+)
+
+// unixSocketPath returns the socket path of an address like unix:///var/run/app.sock.
+func unixSocketPath(address string) (string, bool) {
+	if len(address) < len(unixScheme) || !strings.EqualFold(address[:len(unixScheme)], unixScheme) {
+		return "", false
+	}
+	return address[len(unixScheme):], true
+}
+
+// setBaseURL sets the BaseURL from the service address, the lock must be held. For a unix:// address the BaseURL
+// is set to http://unix and the transport is configured to connect to the socket, so request paths are resolved
+// as usual. The transport is configured once to dial the socket for requests to http://unix, later changes of the
+// socket path only switch the path its dialer connects to, so requests in flight are not affected.
+func (c *RestClient) setBaseURL(address string) {
+	socketPath, ok := unixSocketPath(address)
+	if ok {
+		c.BaseURL = "http://" + unixSocketHost
+	} else {
+		c.BaseURL = address
+	}
+	if socketPath == c.socketPath {
+		return
+	}
+	c.socketPath = socketPath
+	if socketPath == "" {
+		return
+	}
+	c.dialSocketPath.Store(socketPath)
+	if c.socketDialer {
+		// pooled connections still point to the previous socket
+		c.httpClient().CloseIdleConnections()
+		return
+	}
+
+	previous := c.httpClient().Transport
+	c.configureTransport(func(transport *http.Transport) {
+		dial := transport.DialContext
+		if dial == nil {
+			dial = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+		}
+		unixDialer := &net.Dialer{}
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			// requests created before the address changed to TCP still connect to the socket
+			if host, _, _ := net.SplitHostPort(addr); host == unixSocketHost {
+				return unixDialer.DialContext(ctx, "unix", c.dialSocketPath.Load().(string))
+			}
+			return dial(ctx, network, addr)
+		}
+		c.socketDialer = true
+	})
+	// release the connections of the replaced transport, unless it is shared with other clients
+	if closer, ok := previous.(interface{ CloseIdleConnections() }); ok && previous != http.DefaultTransport {
+		closer.CloseIdleConnections()
+	}
+}
+
+// address returns the service address the BaseURL was set from, the lock must be held.
+func (c *RestClient) address() string {
+	if c.socketPath != "" {
+		return unixScheme + c.socketPath
+	}
+	return c.BaseURL
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	config "github.com/kapetacom/sdk-go-config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnixSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "sock")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "app.sock")
+
+	listener, err := net.Listen("unix", socketPath)
+	assert.Nil(t, err)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Path))
+	})}
+	go func() { _ = srv.Serve(listener) }()
+	defer srv.Close()
+
+	get := func(t *testing.T, client *RestClient, path string) string {
+		resp, err := client.GET(client.ResolveURL(path))
+		if !assert.Nil(t, err) {
+			return ""
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	t.Run("should send requests to a unix socket base URL", func(t *testing.T) {
+		client := NewRestClientWithBaseURL("unix://" + socketPath)
+		assert.Equal(t, "/users/1", get(t, client, "/users/1"))
+	})
+	t.Run("should resolve a unix socket service address", func(t *testing.T) {
+		mock := &config.ConfigProviderMock{
+			GetServiceAddressFunc: func(serviceName string, portType string) (string, error) {
+				return "unix://" + socketPath, nil
+			},
+		}
+		client := NewRestClient("resource", false).WithConfigProvider(mock)
+		assert.Nil(t, client.Err())
+		assert.Equal(t, "/users", get(t, client, "/users"))
+		assert.Nil(t, client.Refresh())
+		assert.Equal(t, "http://unix", client.BaseURL)
+	})
+	t.Run("should switch the socket safely while requests are in flight", func(t *testing.T) {
+		otherPath := filepath.Join(dir, "other.sock")
+		otherListener, err := net.Listen("unix", otherPath)
+		assert.Nil(t, err)
+		other := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("other"))
+		})}
+		go func() { _ = other.Serve(otherListener) }()
+		defer other.Close()
+		tcp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("tcp"))
+		}))
+		defer tcp.Close()
+
+		addresses := []string{"unix://" + socketPath, "unix://" + otherPath, tcp.URL}
+		var current atomic.Int32
+		mock := &config.ConfigProviderMock{
+			GetServiceAddressFunc: func(serviceName string, portType string) (string, error) {
+				return addresses[current.Load()], nil
+			},
+		}
+		client := NewRestClient("resource", false).WithConfigProvider(mock)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					body := get(t, client, "/users")
+					assert.Contains(t, []string{"/users", "other", "tcp"}, body)
+				}
+			}()
+		}
+		done := make(chan struct{})
+		refreshed := make(chan struct{})
+		go func() {
+			defer close(refreshed)
+			for i := 1; ; i++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				current.Store(int32(i % len(addresses)))
+				assert.Nil(t, client.Refresh())
+			}
+		}()
+		wg.Wait()
+		close(done)
+		<-refreshed
+
+		for i, expected := range []string{"/users", "other", "tcp"} {
+			current.Store(int32(i))
+			assert.Nil(t, client.Refresh())
+			assert.Equal(t, expected, get(t, client, "/users"))
+		}
+	})
+	t.Run("should reject an address without socket path", func(t *testing.T) {
+		_, err := normalizeBaseURL("unix://")
+		assert.ErrorContains(t, err, "missing socket path")
+	})
+}
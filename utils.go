@@ -1,32 +1,251 @@
 package client
 
 import (
+	"encoding"
 	"fmt"
 	"net/url"
 	"reflect"
 	"strings"
+	"time"
 )
 
+const defaultQueryTag = "query"
+
+var (
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// StructToQueryParams encodes the fields of a struct as query parameters. The parameter name is taken from the
+// `query` tag and defaults to the lower cased field name.
+// Nested structs are flattened using a dotted prefix, e.g. address.city, while the fields of embedded structs are
+// added without a prefix.
+// Slices and arrays are added as repeated parameters, e.g. tags=a&tags=b, or as a single comma separated value when
+// the comma option is set on the tag, e.g. `query:"tags,comma"`.
+// Fields with the omitempty option, e.g. `query:"name,omitempty"`, are skipped when they hold an empty value as
+// defined by encoding/json: false, 0, a nil pointer or interface and an empty string, slice, array or map.
+// time.Time values are formatted as RFC3339, use the format option to choose another layout, e.g.
+// `query:"since,format:2006-01-02"`.
+// Booleans are formatted as true or false, use the bool option to choose other values, e.g. `query:"active,bool:1/0"`,
+// or omitempty to leave out the parameter when false.
+// Unexported fields are skipped.
 func StructToQueryParams(data interface{}) (string, error) {
+	return StructToQueryParamsWithTag(data, defaultQueryTag)
+}
+
+// StructToQueryParamsWithTag works like StructToQueryParams but reads the parameter names and options from the
+// given struct tag instead of `query`, so structs already annotated for another library can be reused.
+// Example:
+//
+//	type Filter struct {
+//		Name string `url:"name,omitempty"`
+//	}
+//	params, err := StructToQueryParamsWithTag(Filter{Name: "john"}, "url")
+func StructToQueryParamsWithTag(data interface{}, tagName string) (string, error) {
+	queryParams, err := structToQueryValues(data, tagName)
+	if err != nil {
+		return "", err
+	}
+	return queryParams.Encode(), nil
+}
+
+// toQueryValues converts maps of parameters to url.Values and encodes anything else as a struct.
+func toQueryValues(data interface{}) (url.Values, error) {
+	switch params := data.(type) {
+	case url.Values:
+		return params, nil
+	case map[string][]string:
+		return params, nil
+	case map[string]string:
+		values := make(url.Values, len(params))
+		for key, value := range params {
+			values.Set(key, value)
+		}
+		return values, nil
+	}
+	return structToQueryValues(data, defaultQueryTag)
+}
+
+// structToQueryValues encodes the fields of a struct as url.Values using the given tag, see StructToQueryParams.
+func structToQueryValues(data interface{}, tagName string) (url.Values, error) {
 	v := reflect.ValueOf(data)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
 	if v.Kind() != reflect.Struct {
-		return "", fmt.Errorf("input data must be a struct")
+		return nil, fmt.Errorf("input data must be a struct")
 	}
 
 	var queryParams = make(url.Values)
+	addStructToQueryParams(queryParams, tagName, "", v)
+
+	return queryParams, nil
+}
 
+// addStructToQueryParams adds the fields of the struct value to queryParams, prefixing the names with prefix.
+func addStructToQueryParams(queryParams url.Values, tagName string, prefix string, v reflect.Value) {
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Type().Field(i)
-		fieldName := field.Tag.Get("query")
+		if !field.IsExported() {
+			// like encoding/json, the exported fields of an embedded struct of an unexported type are promoted
+			if field.Anonymous && field.Type.Kind() == reflect.Struct && isNestedStruct(v.Field(i)) {
+				addStructToQueryParams(queryParams, tagName, prefix, v.Field(i))
+			}
+			continue
+		}
+		fieldName, opts := parseQueryTag(field.Tag.Get(tagName))
 		if fieldName == "" {
 			fieldName = strings.ToLower(field.Name)
 		}
-		fieldValue := fmt.Sprintf("%v", v.Field(i).Interface())
-		queryParams.Add(fieldName, fieldValue)
+
+		fieldValue := v.Field(i)
+		if !fieldValue.CanInterface() {
+			continue
+		}
+		if opts.has("omitempty") && isEmptyValue(fieldValue) {
+			continue
+		}
+		if isNestedStruct(fieldValue) {
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					continue
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			if field.Anonymous {
+				addStructToQueryParams(queryParams, tagName, prefix, fieldValue)
+			} else {
+				addStructToQueryParams(queryParams, tagName, prefix+fieldName+".", fieldValue)
+			}
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.Slice || fieldValue.Kind() == reflect.Array {
+			values := make([]string, fieldValue.Len())
+			for j := range values {
+				values[j] = formatQueryValue(fieldValue.Index(j), opts)
+			}
+			if opts.has("comma") {
+				queryParams.Add(prefix+fieldName, strings.Join(values, ","))
+			} else {
+				for _, value := range values {
+					queryParams.Add(prefix+fieldName, value)
+				}
+			}
+			continue
+		}
+
+		queryParams.Add(prefix+fieldName, formatQueryValue(fieldValue, opts))
 	}
+}
 
-	return queryParams.Encode(), nil
+// isEmptyValue reports whether the value is empty following the encoding/json omitempty rules.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// formatQueryValue formats a single value for use as a query parameter value.
+func formatQueryValue(v reflect.Value, opts tagOptions) string {
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		layout, ok := opts.value("format")
+		if !ok {
+			layout = time.RFC3339
+		}
+		return t.Format(layout)
+	}
+	if v.Kind() == reflect.Bool {
+		if format, ok := opts.value("bool"); ok {
+			trueValue, falseValue, _ := strings.Cut(format, "/")
+			if v.Bool() {
+				return trueValue
+			}
+			return falseValue
+		}
+	}
+	if text, ok := formatWithMethods(v); ok {
+		return text
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// formatWithMethods formats the value with its MarshalText or String method, including methods with a pointer
+// receiver, returning false if it has neither.
+func formatWithMethods(v reflect.Value) (string, bool) {
+	if v.Kind() == reflect.Pointer || !v.IsValid() {
+		return "", false
+	}
+	if !v.CanAddr() {
+		addressable := reflect.New(v.Type()).Elem()
+		addressable.Set(v)
+		v = addressable
+	}
+	value := v.Addr().Interface()
+	if marshaler, ok := value.(encoding.TextMarshaler); ok {
+		if text, err := marshaler.MarshalText(); err == nil {
+			return string(text), true
+		}
+	}
+	if stringer, ok := value.(fmt.Stringer); ok {
+		return stringer.String(), true
+	}
+	return "", false
+}
+
+// tagOptions are the comma separated options following the name in a struct tag.
+type tagOptions []string
+
+// parseQueryTag splits a struct tag into the parameter name and its options.
+func parseQueryTag(tag string) (string, tagOptions) {
+	name, opts, _ := strings.Cut(tag, ",")
+	if opts == "" {
+		return name, nil
+	}
+	return name, strings.Split(opts, ",")
+}
+
+// has returns true if the option is present.
+func (o tagOptions) has(option string) bool {
+	for _, opt := range o {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// value returns the value of an option in the form name:value.
+func (o tagOptions) value(option string) (string, bool) {
+	for _, opt := range o {
+		if name, value, found := strings.Cut(opt, ":"); found && name == option {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// isNestedStruct returns true if the value is a struct, or a pointer to one, that should be flattened rather than
+// formatted as a single value. Structs that know how to format themselves, like time.Time, are not flattened.
+func isNestedStruct(v reflect.Value) bool {
+	t := v.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	pt := reflect.PointerTo(t)
+	return !pt.Implements(stringerType) && !pt.Implements(textMarshalerType)
 }
@@ -1,11 +1,29 @@
 package client
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
+type queryDate struct {
+	Year, Month, Day int
+}
+
+func (d queryDate) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)), nil
+}
+
+type queryPoint struct {
+	x string
+}
+
+func (p *queryPoint) String() string {
+	return "point-" + p.x
+}
+
 func TestStructToQueryParams(t *testing.T) {
 	t.Run("should return error if input data is not a struct", func(t *testing.T) {
 		_, err := StructToQueryParams("not a struct")
@@ -28,4 +46,212 @@ func TestStructToQueryParams(t *testing.T) {
 		_, err := StructToQueryParams("test")
 		assert.Error(t, err)
 	})
+	t.Run("should flatten nested structs with a dotted prefix", func(t *testing.T) {
+		type address struct {
+			City string `query:"city"`
+			Zip  string `query:"zip"`
+		}
+		type input struct {
+			Name    string   `query:"name"`
+			Address address  `query:"address"`
+			Billing *address `query:"billing"`
+		}
+		data := input{
+			Name:    "john",
+			Address: address{City: "copenhagen", Zip: "2100"},
+		}
+		got, err := StructToQueryParams(data)
+		assert.Nil(t, err)
+		expected := "address.city=copenhagen&address.zip=2100&name=john"
+		assert.Equal(t, expected, got)
+	})
+	t.Run("should add embedded struct fields without prefix", func(t *testing.T) {
+		type Paging struct {
+			Page int `query:"page"`
+		}
+		type input struct {
+			Paging
+			Name string `query:"name"`
+		}
+		data := input{
+			Paging: Paging{Page: 2},
+			Name:   "john",
+		}
+		got, err := StructToQueryParams(data)
+		assert.Nil(t, err)
+		expected := "name=john&page=2"
+		assert.Equal(t, expected, got)
+	})
+	t.Run("should add fields of embedded structs with unexported types", func(t *testing.T) {
+		type filter struct {
+			Status string `query:"status"`
+		}
+		type page struct {
+			Limit  int `query:"limit"`
+			offset int
+			filter
+		}
+		type input struct {
+			page
+			*filter
+			Name string `query:"name"`
+		}
+		data := input{
+			page:   page{Limit: 10, offset: 5, filter: filter{Status: "open"}},
+			filter: &filter{Status: "closed"},
+			Name:   "x",
+		}
+		got, err := StructToQueryParams(data)
+		assert.Nil(t, err)
+		expected := "limit=10&name=x&status=open"
+		assert.Equal(t, expected, got)
+	})
+	t.Run("should add slices as repeated params", func(t *testing.T) {
+		type input struct {
+			Tags []string `query:"tags"`
+			Ids  [2]int
+		}
+		data := input{
+			Tags: []string{"a", "b"},
+			Ids:  [2]int{1, 2},
+		}
+		got, err := StructToQueryParams(data)
+		assert.Nil(t, err)
+		expected := "ids=1&ids=2&tags=a&tags=b"
+		assert.Equal(t, expected, got)
+	})
+	t.Run("should join slices with comma when the comma option is set", func(t *testing.T) {
+		type input struct {
+			Tags []string `query:"tags,comma"`
+		}
+		data := input{
+			Tags: []string{"a", "b"},
+		}
+		got, err := StructToQueryParams(data)
+		assert.Nil(t, err)
+		expected := "tags=a%2Cb"
+		assert.Equal(t, expected, got)
+	})
+	t.Run("should skip empty fields with the omitempty option", func(t *testing.T) {
+		type input struct {
+			Name  string   `query:"name,omitempty"`
+			Age   int      `query:"age,omitempty"`
+			Tags  []string `query:"tags,omitempty"`
+			Count int      `query:"count"`
+		}
+		got, err := StructToQueryParams(input{})
+		assert.Nil(t, err)
+		expected := "count=0"
+		assert.Equal(t, expected, got)
+
+		got, err = StructToQueryParams(input{Name: "john", Age: 25})
+		assert.Nil(t, err)
+		expected = "age=25&count=0&name=john"
+		assert.Equal(t, expected, got)
+	})
+	t.Run("should format time values as RFC3339", func(t *testing.T) {
+		type input struct {
+			Since time.Time  `query:"since"`
+			Until *time.Time `query:"until"`
+		}
+		since := time.Date(2024, 2, 1, 10, 30, 0, 0, time.UTC)
+		got, err := StructToQueryParams(input{Since: since, Until: &since})
+		assert.Nil(t, err)
+		expected := "since=2024-02-01T10%3A30%3A00Z&until=2024-02-01T10%3A30%3A00Z"
+		assert.Equal(t, expected, got)
+	})
+	t.Run("should format time values with the format option", func(t *testing.T) {
+		type input struct {
+			Since time.Time `query:"since,format:2006-01-02"`
+		}
+		got, err := StructToQueryParams(input{Since: time.Date(2024, 2, 1, 10, 30, 0, 0, time.UTC)})
+		assert.Nil(t, err)
+		expected := "since=2024-02-01"
+		assert.Equal(t, expected, got)
+	})
+	t.Run("should format structs with their MarshalText method", func(t *testing.T) {
+		type input struct {
+			Day   queryDate   `query:"day"`
+			Until *queryDate  `query:"until"`
+			Days  []queryDate `query:"days,comma"`
+		}
+		day := queryDate{Year: 2024, Month: 1, Day: 2}
+		got, err := StructToQueryParams(input{Day: day, Until: &day, Days: []queryDate{day, day}})
+		assert.Nil(t, err)
+		expected := "day=2024-01-02&days=2024-01-02%2C2024-01-02&until=2024-01-02"
+		assert.Equal(t, expected, got)
+	})
+	t.Run("should format structs with a String method with a pointer receiver", func(t *testing.T) {
+		type input struct {
+			Point  queryPoint  `query:"p"`
+			Points *queryPoint `query:"q"`
+		}
+		got, err := StructToQueryParams(input{Point: queryPoint{x: "x"}, Points: &queryPoint{x: "y"}})
+		assert.Nil(t, err)
+		expected := "p=point-x&q=point-y"
+		assert.Equal(t, expected, got)
+	})
+	t.Run("should skip unexported fields", func(t *testing.T) {
+		type input struct {
+			Name     string `query:"name"`
+			password string
+			age      int
+		}
+		data := input{
+			Name:     "john",
+			password: "secret",
+			age:      25,
+		}
+		got, err := StructToQueryParams(data)
+		assert.Nil(t, err)
+		expected := "name=john"
+		assert.Equal(t, expected, got)
+	})
+}
+
+func TestStructToQueryParamsWithTag(t *testing.T) {
+	t.Run("should read names and options from the given tag", func(t *testing.T) {
+		type input struct {
+			Name  string   `url:"name" query:"ignored"`
+			Age   int      `url:"age,omitempty"`
+			Tags  []string `url:"tags,comma"`
+			Other string
+		}
+		got, err := StructToQueryParamsWithTag(input{Name: "john", Tags: []string{"a", "b"}, Other: "x"}, "url")
+		assert.Nil(t, err)
+		assert.Equal(t, "name=john&other=x&tags=a%2Cb", got)
+	})
+	t.Run("should default to the query tag", func(t *testing.T) {
+		type input struct {
+			Name string `url:"ignored" query:"name"`
+		}
+		got, err := StructToQueryParams(input{Name: "john"})
+		assert.Nil(t, err)
+		assert.Equal(t, "name=john", got)
+	})
+}
+
+func TestStructToQueryParamsBool(t *testing.T) {
+	type input struct {
+		Active  bool  `query:"active,bool:1/0"`
+		Deleted bool  `query:"deleted,bool:yes/no"`
+		Beta    bool  `query:"beta,omitempty"`
+		Draft   *bool `query:"draft,bool:1/0"`
+		Plain   bool  `query:"plain"`
+	}
+	t.Run("should format booleans with the bool option", func(t *testing.T) {
+		draft := true
+		got, err := StructToQueryParams(input{Active: true, Draft: &draft})
+		assert.Nil(t, err)
+		assert.Equal(t, "active=1&deleted=no&draft=1&plain=false", got)
+	})
+	t.Run("should leave out false booleans with omitempty", func(t *testing.T) {
+		got, err := StructToQueryParams(input{Beta: true})
+		assert.Nil(t, err)
+		assert.Contains(t, got, "beta=true")
+
+		got, err = StructToQueryParams(input{})
+		assert.Nil(t, err)
+		assert.NotContains(t, got, "beta")
+	})
 }